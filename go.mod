@@ -2,7 +2,12 @@ module github.com/kael-k/pongo/v2
 
 go 1.19
 
-require github.com/xeipuuv/gojsonschema v1.2.0
+require (
+	github.com/rivo/uniseg v0.4.7
+	github.com/xeipuuv/gojsonschema v1.2.0
+	golang.org/x/text v0.14.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/xeipuuv/gojsonpointer v0.0.0-20180127040702-4e3ac2762d5f // indirect
@@ -0,0 +1,151 @@
+package pongo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// CivilDate is a date without time and location, such as a birthday
+type CivilDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+func NewCivilDate(year int, month time.Month, day int) CivilDate {
+	return CivilDate{Year: year, Month: month, Day: day}
+}
+
+// CivilDateOf return the CivilDate of t in the location of t
+func CivilDateOf(t time.Time) CivilDate {
+	year, month, day := t.Date()
+	return NewCivilDate(year, month, day)
+}
+
+// ParseCivilDate parse a date in the "YYYY-MM-DD" format
+func ParseCivilDate(s string) (CivilDate, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return CivilDate{}, fmt.Errorf("cannot parse date %q, expected the YYYY-MM-DD format", s)
+	}
+	return CivilDateOf(t), nil
+}
+
+func (d CivilDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsValid return true if d is an existing date
+func (d CivilDate) IsValid() bool {
+	return CivilDateOf(d.In(time.UTC)) == d
+}
+
+// In return the time.Time at the midnight of d in loc
+func (d CivilDate) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+func (d CivilDate) Before(d2 CivilDate) bool {
+	if d.Year != d2.Year {
+		return d.Year < d2.Year
+	}
+	if d.Month != d2.Month {
+		return d.Month < d2.Month
+	}
+	return d.Day < d2.Day
+}
+
+func (d CivilDate) After(d2 CivilDate) bool {
+	return d2.Before(d)
+}
+
+func (d CivilDate) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *CivilDate) UnmarshalText(b []byte) (err error) {
+	*d, err = ParseCivilDate(string(b))
+	return err
+}
+
+// civilTimeLayouts are the layouts accepted by ParseCivilTime
+var civilTimeLayouts = []string{"15:04:05.999999999", "15:04"}
+
+// civilTimeInputPattern is the pattern of the strings accepted by civilTimeLayouts,
+// where time.Parse accepts a one-digit hour and a comma before the fraction of second
+const civilTimeInputPattern = `^([01]?[0-9]|2[0-3]):[0-5][0-9](:[0-5][0-9]([.,][0-9]+)?)?$`
+
+// civilTimeOutputPattern is the pattern of the strings produced by CivilTime.String
+const civilTimeOutputPattern = `^([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9](\.[0-9]{1,9})?$`
+
+// CivilTime is a time of the day without date and location, such as an opening hour
+type CivilTime struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+func NewCivilTime(hour, minute, second, nanosecond int) CivilTime {
+	return CivilTime{Hour: hour, Minute: minute, Second: second, Nanosecond: nanosecond}
+}
+
+// CivilTimeOf return the CivilTime of t in the location of t
+func CivilTimeOf(t time.Time) CivilTime {
+	return NewCivilTime(t.Hour(), t.Minute(), t.Second(), t.Nanosecond())
+}
+
+// ParseCivilTime parse a time in the "HH:MM:SS[.fraction]" or in the "HH:MM" format
+func ParseCivilTime(s string) (CivilTime, error) {
+	for _, layout := range civilTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return CivilTimeOf(t), nil
+		}
+	}
+	return CivilTime{}, fmt.Errorf("cannot parse time of day %q, expected the HH:MM:SS or the HH:MM format", s)
+}
+
+// String format the CivilTime as "HH:MM:SS", followed by the fraction of second if it is not zero
+func (t CivilTime) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", t.Nanosecond), "0")
+	}
+	return s
+}
+
+// IsValid return true if t is an existing time of the day
+func (t CivilTime) IsValid() bool {
+	return t.Hour >= 0 && t.Hour < 24 && t.Minute >= 0 && t.Minute < 60 &&
+		t.Second >= 0 && t.Second < 60 && t.Nanosecond >= 0 && t.Nanosecond < int(time.Second)
+}
+
+// On return the time.Time at t of the date d in loc
+func (t CivilTime) On(d CivilDate, loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+}
+
+func (t CivilTime) sinceMidnight() time.Duration {
+	return time.Duration(t.Hour)*time.Hour + time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second + time.Duration(t.Nanosecond)
+}
+
+func (t CivilTime) Before(t2 CivilTime) bool {
+	return t.sinceMidnight() < t2.sinceMidnight()
+}
+
+func (t CivilTime) After(t2 CivilTime) bool {
+	return t2.Before(t)
+}
+
+func (t CivilTime) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *CivilTime) UnmarshalText(b []byte) (err error) {
+	*t, err = ParseCivilTime(string(b))
+	return err
+}
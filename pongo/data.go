@@ -14,8 +14,9 @@ type Data interface{}
 // with the SchemaType associated with the map key. The Data at the key of map[string]Data is then pushed
 // in the Path with Push and then the new DataPointer is passed to the child SchemaType
 type DataPointer struct {
-	root Data
-	path Path
+	root    Data
+	path    Path
+	options ProcessOptions
 }
 
 // NewDataPointer construct a DataPointer
@@ -33,6 +34,13 @@ func NewDataPointer(schemaNode *SchemaNode, data Data) *DataPointer {
 	return dp
 }
 
+// NewDataPointerWithOptions construct a DataPointer carrying the given ProcessOptions
+func NewDataPointerWithOptions(schemaNode *SchemaNode, data Data, options ProcessOptions) *DataPointer {
+	dp := NewDataPointer(schemaNode, data)
+	dp.options = options
+	return dp
+}
+
 // Push a new entry in the DataPointer Path stack
 func (d DataPointer) Push(schemaNode *SchemaNode, data Data, key string) *DataPointer {
 	d.path = *d.path.Push(schemaNode, data, key)
@@ -55,9 +63,14 @@ func (d DataPointer) GetRoot() Data {
 	return d.root
 }
 
+func (d DataPointer) Options() ProcessOptions {
+	return d.options
+}
+
 func (d DataPointer) Clone() *DataPointer {
 	return &DataPointer{
-		root: d.root,
-		path: *d.path.Clone(),
+		root:    d.root,
+		path:    *d.path.Clone(),
+		options: d.options,
 	}
 }
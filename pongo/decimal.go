@@ -0,0 +1,257 @@
+package pongo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	ErrInvalidDecimal      = errors.New("invalid decimal")
+	ErrInvalidRoundingMode = errors.New("invalid rounding mode")
+	ErrInvalidNumberOutput = errors.New("invalid number output")
+)
+
+// maxDecimalExponent is the greatest absolute exponent accepted by parseDecimal,
+// since a huge exponent (e.g. "1e999999999") would allocate a huge number
+const maxDecimalExponent = 1000
+
+var decimalRegexp = regexp.MustCompile(`^[+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)(?:[eE]([+-]?[0-9]+))?$`)
+
+// decimalOutputPattern is the pattern of the decimal strings produced by formatDecimal
+const decimalOutputPattern = `^-?[0-9]+(\.[0-9]+)?$`
+
+// bigIntPattern is the pattern of the strings produced by BigIntType
+const bigIntPattern = `^-?[0-9]+$`
+
+// bigIntInputRegexp is the syntax of the strings cast by BigIntType: unlike a json.Number, which is read
+// as a JSON number (e.g. "1e3"), a string must be a plain integer
+var bigIntInputRegexp = regexp.MustCompile(`^[+-]?[0-9]+$`)
+
+// parseDecimal parse a decimal number such as "-12.50" or "1.5e3", fractions such as "1/3" are not accepted
+func parseDecimal(s string) (*big.Rat, error) {
+	matches := decimalRegexp.FindStringSubmatch(s)
+	if matches == nil {
+		return nil, fmt.Errorf("%w %q", ErrInvalidDecimal, s)
+	}
+	if matches[1] != "" {
+		if exp, err := strconv.Atoi(matches[1]); err != nil || exp > maxDecimalExponent || exp < -maxDecimalExponent {
+			return nil, fmt.Errorf("%w %q: the exponent is out of range", ErrInvalidDecimal, s)
+		}
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrInvalidDecimal, s)
+	}
+	return r, nil
+}
+
+// parseBigInt parse an integer with parseDecimal, so that "1e3" and "1.0" are accepted
+func parseBigInt(s string) (*big.Int, error) {
+	r, err := parseDecimal(s)
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsInt() {
+		return nil, fmt.Errorf("%w: %q is not an integer", ErrInvalidDecimal, s)
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// decimalScale return the number of decimal digits of r; finite is false if r has no finite decimal representation (e.g. 1/3)
+func decimalScale(r *big.Rat) (scale int, finite bool) {
+	var twos, fives int
+	den := new(big.Int).Set(r.Denom())
+	for den.Bit(0) == 0 && den.Sign() != 0 {
+		den.Rsh(den, 1)
+		twos++
+	}
+	five, m := big.NewInt(5), new(big.Int)
+	for {
+		q, rem := new(big.Int).QuoRem(den, five, m)
+		if rem.Sign() != 0 {
+			break
+		}
+		den = q
+		fives++
+	}
+
+	if twos > fives {
+		return twos, den.Cmp(big.NewInt(1)) == 0
+	}
+	return fives, den.Cmp(big.NewInt(1)) == 0
+}
+
+// integerDigits return the number of digits of the integer part of r, 0 if it is zero
+func integerDigits(r *big.Rat) int {
+	q := new(big.Int).Quo(new(big.Int).Abs(r.Num()), r.Denom())
+	if q.Sign() == 0 {
+		return 0
+	}
+	return len(q.String())
+}
+
+// formatDecimal format r with scale decimal digits, or with all its decimal digits if scale is negative
+func formatDecimal(r *big.Rat, scale int) string {
+	if scale < 0 {
+		scale, _ = decimalScale(r)
+	}
+	return r.FloatString(scale)
+}
+
+// describeDecimal format r as a decimal if it has a finite decimal representation, as a fraction (e.g. "1/3") otherwise
+func describeDecimal(r *big.Rat) string {
+	if _, finite := decimalScale(r); finite {
+		return formatDecimal(r, -1)
+	}
+	return r.RatString()
+}
+
+// RoundingMode is how DecimalType rounds the values with more decimal digits than its scale
+type RoundingMode string
+
+const (
+	// RoundingNone rejects the values with more decimal digits than the scale, it is the default RoundingMode
+	RoundingNone RoundingMode = ""
+	// RoundingHalfUp rounds to the nearest neighbour, and away from zero if both neighbours are equidistant
+	RoundingHalfUp RoundingMode = "halfUp"
+	// RoundingHalfEven rounds to the nearest neighbour, and to the even neighbour if both neighbours are equidistant
+	RoundingHalfEven RoundingMode = "halfEven"
+	// RoundingDown rounds toward zero
+	RoundingDown RoundingMode = "down"
+	// RoundingUp rounds away from zero
+	RoundingUp RoundingMode = "up"
+	// RoundingFloor rounds toward negative infinity
+	RoundingFloor RoundingMode = "floor"
+	// RoundingCeiling rounds toward positive infinity
+	RoundingCeiling RoundingMode = "ceiling"
+)
+
+func (m RoundingMode) validate() error {
+	switch m {
+	case RoundingNone, RoundingHalfUp, RoundingHalfEven, RoundingDown, RoundingUp, RoundingFloor, RoundingCeiling:
+		return nil
+	}
+	return fmt.Errorf("%w %q", ErrInvalidRoundingMode, m)
+}
+
+func (m *RoundingMode) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("error decoding RoundingMode, got error: %w", err)
+	}
+	if err := RoundingMode(s).validate(); err != nil {
+		return err
+	}
+
+	*m = RoundingMode(s)
+	return nil
+}
+
+// Round return r rounded to scale decimal digits; ok is false if r has more decimal digits and m is RoundingNone
+func (m RoundingMode) Round(r *big.Rat, scale int) (rounded *big.Rat, ok bool) {
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	num := new(big.Int).Mul(r.Num(), p)
+	q, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return new(big.Rat).SetFrac(q, p), true
+	}
+
+	var away bool
+	switch m {
+	case RoundingNone:
+		return nil, false
+	case RoundingUp:
+		away = true
+	case RoundingFloor:
+		away = num.Sign() < 0
+	case RoundingCeiling:
+		away = num.Sign() > 0
+	case RoundingHalfUp, RoundingHalfEven:
+		c := new(big.Int).Abs(rem)
+		cmp := c.Lsh(c, 1).Cmp(r.Denom())
+		away = cmp > 0 || cmp == 0 && (m == RoundingHalfUp || q.Bit(0) == 1)
+	}
+
+	if away {
+		q.Add(q, big.NewInt(int64(num.Sign())))
+	}
+	return new(big.Rat).SetFrac(q, p), true
+}
+
+// NumberOutput is the data produced by DecimalType and BigIntType
+type NumberOutput string
+
+const (
+	// NumberOutputBig produces a *big.Rat (DecimalType) or a *big.Int (BigIntType), it is the default on SchemaActionParse
+	NumberOutputBig NumberOutput = "big"
+	// NumberOutputString produces a decimal string, it is the default on SchemaActionSerialize
+	NumberOutputString NumberOutput = "string"
+	// NumberOutputNumber produces a json.Number, which encoding/json marshals as a number without loss of precision
+	NumberOutputNumber NumberOutput = "number"
+)
+
+func (o NumberOutput) validate() error {
+	switch o {
+	case NumberOutputBig, NumberOutputString, NumberOutputNumber:
+		return nil
+	}
+	return fmt.Errorf("%w %q", ErrInvalidNumberOutput, o)
+}
+
+func (o *NumberOutput) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("error decoding NumberOutput, got error: %w", err)
+	}
+	if err := NumberOutput(s).validate(); err != nil {
+		return err
+	}
+
+	*o = NumberOutput(s)
+	return nil
+}
+
+// getNumberOutput return the NumberOutput of action, defaulting to NumberOutputBig on SchemaActionParse
+// and to NumberOutputString otherwise
+func getNumberOutput(output *ActionProperty[NumberOutput], action SchemaAction) NumberOutput {
+	if o, ok := output.GetAction(action); ok {
+		return o
+	}
+	if action == SchemaActionParse {
+		return NumberOutputBig
+	}
+	return NumberOutputString
+}
+
+// lintNumberOutput return a finding for every invalid NumberOutput of output
+func lintNumberOutput(path string, output *ActionProperty[NumberOutput]) (findings []LintFinding) {
+	if output == nil {
+		return nil
+	}
+
+	var outputs []NumberOutput
+	if output.Default != nil {
+		outputs = append(outputs, *output.Default)
+	}
+	var actions []string
+	for action := range output.Actions {
+		actions = append(actions, string(action))
+	}
+	sort.Strings(actions)
+	for _, action := range actions {
+		outputs = append(outputs, output.Actions[SchemaAction(action)])
+	}
+
+	for _, o := range outputs {
+		if err := o.validate(); err != nil {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-number-output", "%s", err))
+		}
+	}
+	return findings
+}
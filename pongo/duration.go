@@ -0,0 +1,138 @@
+package pongo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidISODuration = errors.New("invalid ISO 8601 duration")
+
+// isoPeriod is an ISO 8601 duration such as "P1Y2M10DT2H30M" or "-P90D":
+// years, months and days are kept apart from the other components since their length depends on the calendar
+type isoPeriod struct {
+	years, months, days int
+	duration            time.Duration
+}
+
+// parseISOPeriod parse an ISO 8601 duration, optionally negative with a leading "-"; the weeks are converted to days
+func parseISOPeriod(s string) (p isoPeriod, err error) {
+	var sign = 1
+	var str = s
+	if strings.HasPrefix(str, "-") {
+		sign, str = -1, str[1:]
+	}
+
+	matches := isoDurationRegexp.FindStringSubmatch(str)
+	if matches == nil || !isISODuration(str) {
+		return p, fmt.Errorf("%w %q", ErrInvalidISODuration, s)
+	}
+
+	var values [6]int
+	for i, m := range matches[1:7] {
+		if m == "" {
+			continue
+		}
+		if values[i], err = strconv.Atoi(m); err != nil {
+			return p, fmt.Errorf("%w %q: %s", ErrInvalidISODuration, s, err)
+		}
+	}
+	if values[2] > (math.MaxInt-values[3])/7 {
+		return p, fmt.Errorf("%w %q: overflow", ErrInvalidISODuration, s)
+	}
+	p.years, p.months, p.days = sign*values[0], sign*values[1], sign*(values[2]*7+values[3])
+
+	var d time.Duration
+	for _, c := range []struct {
+		n    int
+		unit time.Duration
+	}{{values[4], time.Hour}, {values[5], time.Minute}} {
+		if int64(c.n) > (math.MaxInt64-int64(d))/int64(c.unit) {
+			return p, fmt.Errorf("%w %q: overflow", ErrInvalidISODuration, s)
+		}
+		d += time.Duration(c.n) * c.unit
+	}
+	if seconds := matches[7]; seconds != "" {
+		sd, err := time.ParseDuration(strings.Replace(seconds, ",", ".", 1) + "s")
+		if err != nil {
+			return p, fmt.Errorf("%w %q: %s", ErrInvalidISODuration, s, err)
+		}
+		if sd > math.MaxInt64-d {
+			return p, fmt.Errorf("%w %q: overflow", ErrInvalidISODuration, s)
+		}
+		d += sd
+	}
+	p.duration = time.Duration(sign) * d
+
+	return p, nil
+}
+
+// Duration return the period as a time.Duration, the days are 24 hours long.
+// An error is returned if the period has years or months, since their length is not fixed
+func (p isoPeriod) Duration() (time.Duration, error) {
+	if p.years != 0 || p.months != 0 {
+		return 0, fmt.Errorf("%w: years and months have no fixed duration", ErrInvalidISODuration)
+	}
+	if int64(p.days) > math.MaxInt64/int64(24*time.Hour) || int64(p.days) < math.MinInt64/int64(24*time.Hour) {
+		return 0, fmt.Errorf("%w: overflow", ErrInvalidISODuration)
+	}
+	days := time.Duration(p.days) * 24 * time.Hour
+	if p.duration > 0 && days > math.MaxInt64-p.duration || p.duration < 0 && days < math.MinInt64-p.duration {
+		return 0, fmt.Errorf("%w: overflow", ErrInvalidISODuration)
+	}
+	return days + p.duration, nil
+}
+
+// AddTo return t shifted by the period, the years, months and days are added in the calendar of t
+func (p isoPeriod) AddTo(t time.Time) time.Time {
+	return t.AddDate(p.years, p.months, p.days).Add(p.duration)
+}
+
+// formatISODuration format d as an ISO 8601 duration in hours, minutes and seconds, such as "PT1H30M" or "-PT0.5S"
+func formatISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	var u = uint64(d)
+	if d < 0 {
+		b.WriteString("-")
+		u = -u
+	}
+	b.WriteString("PT")
+
+	hours, u := u/uint64(time.Hour), u%uint64(time.Hour)
+	minutes, u := u/uint64(time.Minute), u%uint64(time.Minute)
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	if u > 0 {
+		seconds := strconv.FormatUint(u/uint64(time.Second), 10)
+		if nanos := u % uint64(time.Second); nanos > 0 {
+			seconds += strings.TrimRight(fmt.Sprintf(".%09d", nanos), "0")
+		}
+		fmt.Fprintf(&b, "%sS", seconds)
+	}
+
+	return b.String()
+}
+
+// parseDuration parse a Go duration string (e.g. "1h30m") or an ISO 8601 duration without years and months
+func parseDuration(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	p, err := parseISOPeriod(s)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse duration %q, expected a Go or an ISO 8601 duration: %w", s, err)
+	}
+	return p.Duration()
+}
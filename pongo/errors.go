@@ -1,6 +1,7 @@
 package pongo
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -58,15 +59,46 @@ func (s SchemaElementError) Error() error {
 	return s.err
 }
 
+// Data return the data at the error Path, where the data of the sensitive SchemaNode(s) is redacted
+func (s SchemaElementError) Data() Data {
+	return redactPathValue(s.path)
+}
+
 func (s SchemaError) Error() string {
 	var errs []string
 	for _, v := range s.Errors {
-		errs = append(errs, fmt.Sprintf("path: %s, pathData: %#v, error: %s", v.path, v.path.Value(), v.err))
+		errs = append(errs, fmt.Sprintf("path: %s, pathData: %#v, error: %s", v.path, v.Data(), v.err))
 	}
 
 	return fmt.Sprintf("the schema encountered the followed error(s) = [%s]", strings.Join(errs, "; "))
 }
 
+// MarshalJSON marshal the errors as {"errors": [{"path": ..., "data": ..., "error": ...}]},
+// where the data of the sensitive SchemaNode(s) is redacted
+func (s SchemaError) MarshalJSON() ([]byte, error) {
+	type elementError struct {
+		Path  string          `json:"path"`
+		Data  json.RawMessage `json:"data"`
+		Error string          `json:"error"`
+	}
+
+	var errs = []elementError{}
+	for _, v := range s.Errors {
+		data, err := json.Marshal(v.Data())
+		if err != nil {
+			// data not JSON encodable (e.g. a channel) is reported with its Go representation
+			if data, err = json.Marshal(fmt.Sprintf("%#v", v.Data())); err != nil {
+				return nil, err
+			}
+		}
+		errs = append(errs, elementError{Path: v.path.String(), Data: data, Error: v.err.Error()})
+	}
+
+	return json.Marshal(struct {
+		Errors []elementError `json:"errors"`
+	}{Errors: errs})
+}
+
 func (s SchemaError) Append(path Path, err error) *SchemaError {
 	s.Errors = append(s.Errors, SchemaElementError{
 		path: path,
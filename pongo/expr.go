@@ -0,0 +1,720 @@
+package pongo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// this file implements the small expression language evaluated by ExprType.
+// The language is sandboxed: an expression can only read the data being processed and call the builtin functions.
+//
+//   - literals: numbers (1, 2.5), strings ('a' or "a"), true, false, null
+//   - references: @ is the current value, ^ the parent value (^^ the grandparent and so on), $ the root value;
+//     fields and items are accessed with .key and [index], a missing field evaluates to null
+//   - operators, from the lowest precedence: ||, &&, == !=, < <= > >=, + -, * / %, unary ! and -
+//   - functions: len(x) return the length of a string (in runes), a list or an object; now() return the current time
+//
+// Numbers are always compared as float64, times can be compared with other times or with RFC 3339 strings;
+// two strings are compared as times if both are RFC 3339 times (so that their UTC offsets are taken into account),
+// as text otherwise
+
+var ErrInvalidExpr = errors.New("invalid expression")
+
+type exprTokenKind int
+
+const (
+	exprTokenEOF exprTokenKind = iota
+	exprTokenNumber
+	exprTokenString
+	exprTokenIdent
+	exprTokenRef
+	exprTokenOperator
+)
+
+type exprToken struct {
+	kind  exprTokenKind
+	value string
+	pos   int
+}
+
+var exprOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "+", "-", "*", "/", "%", "!", "(", ")", "[", "]", ".", ","}
+
+func lexExpr(expression string) ([]exprToken, error) {
+	var tokens []exprToken
+
+	for i := 0; i < len(expression); {
+		r, size := utf8.DecodeRuneInString(expression[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r >= '0' && r <= '9':
+			start := i
+			for i < len(expression) && (expression[i] >= '0' && expression[i] <= '9' || expression[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, exprToken{exprTokenNumber, expression[start:i], start})
+		case r == '\'' || r == '"':
+			start := i
+			value, n, err := lexExprString(expression[i:])
+			if err != nil {
+				return nil, fmt.Errorf("%w at position %d: %s", ErrInvalidExpr, start, err)
+			}
+			i += n
+			tokens = append(tokens, exprToken{exprTokenString, value, start})
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(expression) {
+				r, size = utf8.DecodeRuneInString(expression[i:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				i += size
+			}
+			tokens = append(tokens, exprToken{exprTokenIdent, expression[start:i], start})
+		case r == '@' || r == '$':
+			tokens = append(tokens, exprToken{exprTokenRef, string(r), i})
+			i++
+		case r == '^':
+			start := i
+			for i < len(expression) && expression[i] == '^' {
+				i++
+			}
+			tokens = append(tokens, exprToken{exprTokenRef, expression[start:i], start})
+		default:
+			found := false
+			for _, op := range exprOperators {
+				if strings.HasPrefix(expression[i:], op) {
+					tokens = append(tokens, exprToken{exprTokenOperator, op, i})
+					i += len(op)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("%w: unexpected character %q at position %d", ErrInvalidExpr, r, i)
+			}
+		}
+	}
+
+	return append(tokens, exprToken{exprTokenEOF, "", len(expression)}), nil
+}
+
+// lexExprString read a quoted string at the beginning of s, returning its value and the length of the quoted string
+func lexExprString(s string) (string, int, error) {
+	quote := s[0]
+	var value strings.Builder
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case quote:
+			return value.String(), i + 1, nil
+		case '\\':
+			i++
+			if i >= len(s) {
+				break
+			}
+			switch s[i] {
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			case '\\', '\'', '"':
+				value.WriteByte(s[i])
+			default:
+				return "", 0, fmt.Errorf("invalid escape sequence \\%c", s[i])
+			}
+		default:
+			value.WriteByte(s[i])
+		}
+	}
+
+	return "", 0, errors.New("unterminated string")
+}
+
+var exprBinaryPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+// exprFunctions maps the builtin functions to their number of arguments
+var exprFunctions = map[string]int{
+	"len": 1,
+	"now": 0,
+}
+
+type exprParser struct {
+	expression string
+	tokens     []exprToken
+	pos        int
+}
+
+// compileExpr parse expression in an AST ready to be evaluated
+func compileExpr(expression string) (exprNode, error) {
+	tokens, err := lexExpr(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 1 {
+		return nil, fmt.Errorf("%w: empty expression", ErrInvalidExpr)
+	}
+
+	p := &exprParser{expression: expression, tokens: tokens}
+	node, err := p.parseExpr(1)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != exprTokenEOF {
+		return nil, p.errorf(t, "unexpected %q", t.value)
+	}
+
+	return node, nil
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	t := p.tokens[p.pos]
+	if t.kind != exprTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *exprParser) errorf(t exprToken, format string, a ...any) error {
+	if t.kind == exprTokenEOF {
+		return fmt.Errorf("%w: unexpected end of expression", ErrInvalidExpr)
+	}
+	return fmt.Errorf("%w at position %d: %s", ErrInvalidExpr, t.pos, fmt.Sprintf(format, a...))
+}
+
+func (p *exprParser) expect(operator string) error {
+	t := p.next()
+	if t.kind != exprTokenOperator || t.value != operator {
+		return p.errorf(t, "expected %q, got %q", operator, t.value)
+	}
+	return nil
+}
+
+func (p *exprParser) parseExpr(minPrecedence int) (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		precedence, ok := exprBinaryPrecedence[t.value]
+		if t.kind != exprTokenOperator || !ok || precedence < minPrecedence {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseExpr(precedence + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = exprBinary{Op: t.value, Left: left, Right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if t := p.peek(); t.kind == exprTokenOperator && (t.value == "!" || t.value == "-") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return exprUnary{Op: t.value, Operand: operand}, nil
+	}
+
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	return p.parsePostfix(node)
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	t := p.next()
+
+	switch t.kind {
+	case exprTokenNumber:
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, p.errorf(t, "invalid number %q", t.value)
+		}
+		return exprLiteral{Value: f}, nil
+	case exprTokenString:
+		return exprLiteral{Value: t.value}, nil
+	case exprTokenRef:
+		return exprRef{Ref: t.value}, nil
+	case exprTokenIdent:
+		switch t.value {
+		case "true":
+			return exprLiteral{Value: true}, nil
+		case "false":
+			return exprLiteral{Value: false}, nil
+		case "null":
+			return exprLiteral{Value: nil}, nil
+		}
+		return p.parseCall(t)
+	case exprTokenOperator:
+		if t.value == "(" {
+			node, err := p.parseExpr(1)
+			if err != nil {
+				return nil, err
+			}
+			return node, p.expect(")")
+		}
+	}
+
+	return nil, p.errorf(t, "unexpected %q", t.value)
+}
+
+func (p *exprParser) parseCall(name exprToken) (exprNode, error) {
+	arity, ok := exprFunctions[name.value]
+	if !ok {
+		return nil, p.errorf(name, "unknown identifier %q", name.value)
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	call := exprCall{Name: name.value}
+	if t := p.peek(); t.kind != exprTokenOperator || t.value != ")" {
+		for {
+			arg, err := p.parseExpr(1)
+			if err != nil {
+				return nil, err
+			}
+			call.Args = append(call.Args, arg)
+
+			if t = p.peek(); t.kind != exprTokenOperator || t.value != "," {
+				break
+			}
+			p.next()
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	if len(call.Args) != arity {
+		return nil, p.errorf(name, "function %s expects %d argument(s), got %d", name.value, arity, len(call.Args))
+	}
+	return call, nil
+}
+
+func (p *exprParser) parsePostfix(node exprNode) (exprNode, error) {
+	for {
+		t := p.peek()
+		if t.kind != exprTokenOperator {
+			return node, nil
+		}
+
+		switch t.value {
+		case ".":
+			p.next()
+			key := p.next()
+			if key.kind != exprTokenIdent {
+				return nil, p.errorf(key, "expected a field name after \".\", got %q", key.value)
+			}
+			node = exprMember{Target: node, Key: key.value}
+		case "[":
+			p.next()
+			index, err := p.parseExpr(1)
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expect("]"); err != nil {
+				return nil, err
+			}
+			node = exprIndex{Target: node, Index: index}
+		default:
+			return node, nil
+		}
+	}
+}
+
+// exprEnv is the data available to an expression during its evaluation
+type exprEnv struct {
+	dataPointer *DataPointer
+	now         func() time.Time
+}
+
+type exprNode interface {
+	eval(env exprEnv) (interface{}, error)
+}
+
+type exprLiteral struct {
+	Value interface{}
+}
+
+func (e exprLiteral) eval(_ exprEnv) (interface{}, error) {
+	return e.Value, nil
+}
+
+type exprRef struct {
+	Ref string
+}
+
+func (e exprRef) eval(env exprEnv) (interface{}, error) {
+	switch e.Ref {
+	case "@":
+		return exprNormalize(env.dataPointer.Get()), nil
+	case "$":
+		return exprNormalize(env.dataPointer.GetRoot()), nil
+	}
+
+	// e.Ref is a sequence of "^", one for every level
+	elements := env.dataPointer.Path().Elements()
+	i := len(elements) - 1 - len(e.Ref)
+	if i < 0 {
+		return nil, nil
+	}
+	if elements[i].hasOverride {
+		return exprNormalize(elements[i].override), nil
+	}
+	return exprNormalize(elements[i].data), nil
+}
+
+type exprMember struct {
+	Target exprNode
+	Key    string
+}
+
+func (e exprMember) eval(env exprEnv) (interface{}, error) {
+	target, err := e.Target.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	return exprGetField(target, e.Key)
+}
+
+type exprIndex struct {
+	Target exprNode
+	Index  exprNode
+}
+
+func (e exprIndex) eval(env exprEnv) (interface{}, error) {
+	target, err := e.Target.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	index, err := e.Index.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch i := index.(type) {
+	case string:
+		return exprGetField(target, i)
+	case float64:
+		if target == nil {
+			return nil, nil
+		}
+		v := reflect.ValueOf(target)
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return nil, fmt.Errorf("cannot get an index of %T", target)
+		}
+		if i != math.Trunc(i) {
+			return nil, errors.New("invalid index, expected an integer")
+		}
+		if i < 0 || int(i) >= v.Len() {
+			return nil, nil
+		}
+		return exprNormalize(v.Index(int(i)).Interface()), nil
+	}
+
+	return nil, fmt.Errorf("invalid index of type %T", index)
+}
+
+func exprGetField(target interface{}, key string) (interface{}, error) {
+	if target == nil {
+		return nil, nil
+	}
+	if m, ok := target.(map[string]interface{}); ok {
+		return exprNormalize(m[key]), nil
+	}
+
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("cannot get field %s of %T", key, target)
+	}
+	value := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+	if !value.IsValid() {
+		return nil, nil
+	}
+	return exprNormalize(value.Interface()), nil
+}
+
+type exprUnary struct {
+	Op      string
+	Operand exprNode
+}
+
+func (e exprUnary) eval(env exprEnv) (interface{}, error) {
+	operand, err := e.Operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := operand.(type) {
+	case bool:
+		if e.Op == "!" {
+			return !v, nil
+		}
+	case float64:
+		if e.Op == "-" {
+			return -v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid operand of type %T for operator %s", operand, e.Op)
+}
+
+type exprBinary struct {
+	Op    string
+	Left  exprNode
+	Right exprNode
+}
+
+func (e exprBinary) eval(env exprEnv) (interface{}, error) {
+	left, err := e.Left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.Op == "&&" || e.Op == "||" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, fmt.Errorf("invalid operand of type %T for operator %s, expected a bool", left, e.Op)
+		}
+		// short-circuit evaluation
+		if l == (e.Op == "||") {
+			return l, nil
+		}
+		right, err := e.Right.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("invalid operand of type %T for operator %s, expected a bool", right, e.Op)
+		}
+		return r, nil
+	}
+
+	right, err := e.Right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.Op {
+	case "==":
+		return exprEqual(left, right), nil
+	case "!=":
+		return !exprEqual(left, right), nil
+	case "<", "<=", ">", ">=":
+		cmp, err := exprCompare(left, right)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operands of type %T and %T for operator %s: %w", left, right, e.Op, err)
+		}
+		switch e.Op {
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		}
+		return cmp >= 0, nil
+	}
+
+	if l, ok := left.(string); ok && e.Op == "+" {
+		if r, ok := right.(string); ok {
+			return l + r, nil
+		}
+	}
+
+	l, lok := left.(float64)
+	r, rok := right.(float64)
+	if !lok || !rok {
+		return nil, fmt.Errorf("invalid operands of type %T and %T for operator %s", left, right, e.Op)
+	}
+	switch e.Op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return l / r, nil
+	}
+	if r == 0 {
+		return nil, errors.New("modulo by zero")
+	}
+	return math.Mod(l, r), nil
+}
+
+type exprCall struct {
+	Name string
+	Args []exprNode
+}
+
+func (e exprCall) eval(env exprEnv) (interface{}, error) {
+	var args []interface{}
+	for _, a := range e.Args {
+		v, err := a.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+
+	switch e.Name {
+	case "now":
+		if env.now != nil {
+			return env.now(), nil
+		}
+		return time.Now(), nil
+	case "len":
+		switch v := args[0].(type) {
+		case nil:
+			return float64(0), nil
+		case string:
+			return float64(utf8.RuneCountInString(v)), nil
+		}
+		v := reflect.ValueOf(args[0])
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return float64(v.Len()), nil
+		}
+		return nil, fmt.Errorf("invalid argument of type %T for function len", args[0])
+	}
+
+	return nil, fmt.Errorf("unknown function %s", e.Name)
+}
+
+// exprNormalize convert the numbers to float64, so they can be compared regardless of their Go type
+func exprNormalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, bool, string, float64, time.Time:
+		return v
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32:
+		return v.Float()
+	}
+
+	return value
+}
+
+// exprTime return value as a time.Time if it is a time.Time or a RFC 3339 string
+func exprTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+// exprStringTimes return the two values as time.Time if both are RFC 3339 strings
+func exprStringTimes(left, right interface{}) (l, r time.Time, ok bool) {
+	ls, lok := left.(string)
+	rs, rok := right.(string)
+	if !lok || !rok {
+		return l, r, false
+	}
+	l, lok = exprTime(ls)
+	r, rok = exprTime(rs)
+	return l, r, lok && rok
+}
+
+func exprEqual(left, right interface{}) bool {
+	if l, r, ok := exprStringTimes(left, right); ok {
+		return l.Equal(r)
+	}
+
+	_, leftIsTime := left.(time.Time)
+	_, rightIsTime := right.(time.Time)
+	if leftIsTime || rightIsTime {
+		l, lok := exprTime(left)
+		r, rok := exprTime(right)
+		return lok && rok && l.Equal(r)
+	}
+
+	return reflect.DeepEqual(left, right)
+}
+
+func exprCompare(left, right interface{}) (int, error) {
+	switch l := left.(type) {
+	case float64:
+		if r, ok := right.(float64); ok {
+			switch {
+			case l < r:
+				return -1, nil
+			case l > r:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case string:
+		if r, ok := right.(string); ok {
+			if _, _, ok = exprStringTimes(l, r); !ok {
+				return strings.Compare(l, r), nil
+			}
+		}
+	}
+
+	l, lok := exprTime(left)
+	r, rok := exprTime(right)
+	if lok && rok {
+		switch {
+		case l.Before(r):
+			return -1, nil
+		case l.After(r):
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	return 0, errors.New("values are not comparable")
+}
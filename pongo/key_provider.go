@@ -0,0 +1,147 @@
+package pongo
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var ErrKeyProviderNotRegistered = errors.New("key provider not registered")
+var ErrKeyProviderAlreadyRegistered = errors.New("key provider already registered")
+var ErrKeyNotFound = errors.New("key not found")
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+// KeyProvider encrypts and decrypts the data of EncryptedType.
+// The ciphertext must embed the ID of the key used to encrypt it, so the data encrypted with an old key
+// can still be decrypted after a key rotation
+type KeyProvider interface {
+	Encrypt(plaintext []byte) (ciphertext string, err error)
+	Decrypt(ciphertext string) (plaintext []byte, err error)
+}
+
+// AESGCMKeyProvider is a KeyProvider using AES-GCM, the ciphertext format is "keyID:base64(nonce + sealed data)"
+// and the key ID is authenticated as additional data.
+// The data is always encrypted with the current key and decrypted with the key referenced by the ciphertext
+type AESGCMKeyProvider struct {
+	currentKeyID string
+	keys         map[string]cipher.AEAD
+}
+
+// NewAESGCMKeyProvider return a new AESGCMKeyProvider, keys maps the key IDs to 16, 24 or 32 bytes AES keys
+// and currentKeyID is the ID of the key used to encrypt. The key IDs cannot be empty or contain ":"
+func NewAESGCMKeyProvider(currentKeyID string, keys map[string][]byte) (*AESGCMKeyProvider, error) {
+	if _, ok := keys[currentKeyID]; !ok {
+		return nil, fmt.Errorf("%w: current key %s", ErrKeyNotFound, currentKeyID)
+	}
+
+	p := &AESGCMKeyProvider{
+		currentKeyID: currentKeyID,
+		keys:         map[string]cipher.AEAD{},
+	}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid key ID %q, the key ID cannot be empty or contain \":\"", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s: %w", id, err)
+		}
+		p.keys[id] = aead
+	}
+
+	return p, nil
+}
+
+func (p *AESGCMKeyProvider) Encrypt(plaintext []byte) (string, error) {
+	aead := p.keys[p.currentKeyID]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("cannot generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(p.currentKeyID))
+
+	return p.currentKeyID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (p *AESGCMKeyProvider) Decrypt(ciphertext string) ([]byte, error) {
+	id, encoded, ok := strings.Cut(ciphertext, ":")
+	if !ok {
+		return nil, fmt.Errorf("%w: no key ID found", ErrInvalidCiphertext)
+	}
+	aead, ok := p.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, id)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCiphertext, err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidCiphertext)
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(id))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCiphertext, err)
+	}
+	return plaintext, nil
+}
+
+var keyProviders = map[string]KeyProvider{}
+var keyProvidersMutex sync.RWMutex
+
+// RegisterKeyProvider register globally a KeyProvider with the given name, which is used by EncryptedType
+// to reference the KeyProvider. An error is returned if the name is invalid or already registered
+func RegisterKeyProvider(name string, provider KeyProvider) error {
+	if err := ValidateSchemaTypeID(name); err != nil {
+		return err
+	}
+
+	keyProvidersMutex.Lock()
+	defer keyProvidersMutex.Unlock()
+
+	if _, ok := keyProviders[name]; ok {
+		return fmt.Errorf("%w: %s", ErrKeyProviderAlreadyRegistered, name)
+	}
+	keyProviders[name] = provider
+
+	return nil
+}
+
+// GetKeyProvider return the KeyProvider registered with name
+func GetKeyProvider(name string) (KeyProvider, error) {
+	keyProvidersMutex.RLock()
+	defer keyProvidersMutex.RUnlock()
+
+	provider, ok := keyProviders[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKeyProviderNotRegistered, name)
+	}
+	return provider, nil
+}
+
+// KeyProviders return the names of the registered KeyProvider(s) in alphabetical order
+func KeyProviders() []string {
+	keyProvidersMutex.RLock()
+	defer keyProvidersMutex.RUnlock()
+
+	var names []string
+	for name := range keyProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,237 @@
+package pongo
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// LintSeverity describe how severe is a LintFinding
+// * LintSeverityError: the schema (or a part of it) can never validate any data
+// * LintSeverityWarning: the schema is valid, but some constraint is useless or suspicious
+type LintSeverity string
+
+const (
+	LintSeverityError   LintSeverity = "ERROR"
+	LintSeverityWarning LintSeverity = "WARNING"
+)
+
+// LintFinding is a single issue found by Lint.
+// Path has the same format of Path.String(), but keys are the schema keys instead of the data keys
+// (for example, ListType items are represented with the "[*]" key)
+type LintFinding struct {
+	Path     string       `json:"path"`
+	Severity LintSeverity `json:"severity"`
+	Rule     string       `json:"rule"`
+	Message  string       `json:"message"`
+}
+
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s %s [%s]: %s", f.Severity, f.Path, f.Rule, f.Message)
+}
+
+// NewLintFinding is a constructor for LintFinding, the message is built with fmt.Sprintf
+func NewLintFinding(path string, severity LintSeverity, rule string, format string, a ...any) LintFinding {
+	return LintFinding{
+		Path:     path,
+		Severity: severity,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, a...),
+	}
+}
+
+// LintableSchemaType is a SchemaType which can check its own configuration.
+// Lint must only check the SchemaType itself, the children (if any) are visited by the Lint function;
+// path is the path of the SchemaNode wrapping the SchemaType and should be used in the returned LintFinding(s)
+type LintableSchemaType interface {
+	SchemaType
+
+	Lint(path string) []LintFinding
+}
+
+// LintRule is a custom rule executed by Lint on every SchemaNode of the schema,
+// it allows to add checks for SchemaType(s) which cannot implement LintableSchemaType
+type LintRule func(path string, schemaNode *SchemaNode) []LintFinding
+
+// Lint walks the schema and return all the contradictory or useless constraints found.
+// Every SchemaType implementing LintableSchemaType is checked, then all the custom rules are run on every SchemaNode
+func Lint(schema SchemaType, rules ...LintRule) []LintFinding {
+	l := linter{
+		rules:   rules,
+		visited: map[*SchemaNode]struct{}{},
+	}
+	root := Schema(schema)
+	return l.lint(lintPath("", "", root), root)
+}
+
+type linter struct {
+	rules   []LintRule
+	visited map[*SchemaNode]struct{}
+}
+
+type lintChild struct {
+	key  string
+	node *SchemaNode
+}
+
+func (l linter) lint(path string, schemaNode *SchemaNode) (findings []LintFinding) {
+	if schemaNode == nil {
+		return []LintFinding{NewLintFinding(path, LintSeverityError, "nil-schema", "SchemaNode is nil")}
+	}
+	if _, ok := l.visited[schemaNode]; ok {
+		return nil
+	}
+	l.visited[schemaNode] = struct{}{}
+
+	schemaType := lintUnwrap(schemaNode.Type())
+	if schemaType == nil {
+		return []LintFinding{NewLintFinding(path, LintSeverityError, "no-schema-type", "%s", ErrNoSchemaTypeSet)}
+	}
+
+	if lintable, ok := schemaType.(LintableSchemaType); ok {
+		findings = append(findings, lintable.Lint(path)...)
+	}
+	for i, validator := range schemaNode.Validators {
+		if validator == nil {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "unresolved-validator", "validator %d is nil", i))
+		} else if validator.fn == nil {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "unresolved-validator", "validator %s has not been resolved, use NewSchemaValidator", validator.Name))
+		}
+	}
+	if schemaNode.Sensitive != nil {
+		if err := schemaNode.Sensitive.validate(); err != nil {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-mask", "%s", err))
+		}
+	}
+	for _, rule := range l.rules {
+		findings = append(findings, rule(path, schemaNode)...)
+	}
+
+	for _, child := range lintChildren(schemaType) {
+		findings = append(findings, l.lint(lintPath(path, child.key, child.node), child.node)...)
+	}
+
+	return findings
+}
+
+// lintUnwrap return the SchemaType that actually holds the constraints
+func lintUnwrap(schemaType SchemaType) SchemaType {
+	switch t := schemaType.(type) {
+	case *DecoratedType:
+		if t != nil {
+			return lintUnwrap(t.OriginalType)
+		}
+	case DecoratedType:
+		return lintUnwrap(t.OriginalType)
+	case *SchemaNode:
+		if t != nil {
+			return lintUnwrap(t.Type())
+		}
+	}
+	return schemaType
+}
+
+// lintChildren return the children of schemaType with the key used to build their lint path
+func lintChildren(schemaType SchemaType) []lintChild {
+	var children []lintChild
+
+	switch t := schemaType.(type) {
+	case *ObjectType:
+		for _, key := range t.Keys() {
+			children = append(children, lintChild{key, t.SchemaMap[key]})
+		}
+	case *ListType:
+		// a nil Type is already reported by ListType.Lint
+		if t.Type != nil {
+			children = append(children, lintChild{"[*]", t.Type})
+		}
+		if t.Contains != nil {
+			children = append(children, lintChild{"contains", t.Contains})
+		}
+	case *TupleType:
+		// nil items are already reported by TupleType.Lint
+		for i, item := range t.Items {
+			if item != nil {
+				children = append(children, lintChild{fmt.Sprintf("[%d]", i), item})
+			}
+		}
+		if t.Additional != nil {
+			children = append(children, lintChild{"[+]", t.Additional})
+		}
+	case *NotType:
+		// a nil Type is already reported by NotType.Lint
+		if t.Type != nil {
+			children = append(children, lintChild{"not", t.Type})
+		}
+	case *EncryptedType:
+		// a nil Type is already reported by EncryptedType.Lint
+		if t.Type != nil {
+			children = append(children, lintChild{"plaintext", t.Type})
+		}
+	case *DiscriminatorType:
+		for _, key := range t.keys() {
+			children = append(children, lintChild{key, t.Mapping[key]})
+		}
+	case *IfThenElseType:
+		for _, child := range []lintChild{{"if", t.If}, {"then", t.Then}, {"else", t.Else}} {
+			if child.node != nil {
+				children = append(children, child)
+			}
+		}
+	case ParentSchema:
+		for i, child := range t.Children() {
+			children = append(children, lintChild{fmt.Sprintf("[%d]", i), child})
+		}
+	}
+
+	return children
+}
+
+func lintPath(parent string, key string, schemaNode *SchemaNode) string {
+	schemaTypeID := "nil"
+	if schemaNode != nil && schemaNode.Type() != nil {
+		schemaTypeID = SchemaTypeID(schemaNode)
+	}
+
+	return fmt.Sprintf("%s%s%s<%s>", parent, PathSeparator, key, schemaTypeID)
+}
+
+// lintLength is a helper for the SchemaType(s) which have a MinLen and MaxLen property
+func lintLength(path string, minLen, maxLen *NumberProperty[int]) (findings []LintFinding) {
+	mi, okMin := minLen.Get()
+	ma, okMax := maxLen.Get()
+
+	if okMin && mi < 0 {
+		findings = append(findings, NewLintFinding(path, LintSeverityWarning, "negative-length", "min length is negative (%d)", mi))
+	}
+	if okMax && ma < 0 {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "negative-length", "max length is negative (%d)", ma))
+	}
+	if okMin && okMax && mi > ma {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "contradictory-length", "min length %d is greater than max length %d", mi, ma))
+	}
+
+	return findings
+}
+
+// lintDuplicateChildren return the indexes pairs of the children which are marshalled to the same pongo schema
+func lintDuplicateChildren(children SchemaList) [][2]int {
+	var duplicates [][2]int
+	var marshalled = map[string]int{}
+
+	for i, child := range children {
+		if child == nil || child.Type() == nil {
+			continue
+		}
+		j, err := json.Marshal(child)
+		if err != nil {
+			continue
+		}
+		if first, ok := marshalled[string(j)]; ok {
+			duplicates = append(duplicates, [2]int{first, i})
+			continue
+		}
+		marshalled[string(j)] = i
+	}
+
+	return duplicates
+}
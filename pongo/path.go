@@ -75,6 +75,11 @@ func (path Path) String() string {
 	var stringPath = ""
 
 	for _, pathElement := range path.elements {
+		// the element of a missing key (e.g. a required key not defined in an ObjectType) has no SchemaNode
+		if pathElement.schemaNode == nil || pathElement.schemaNode.Type() == nil {
+			stringPath += fmt.Sprintf("%s%s", PathSeparator, pathElement.key)
+			continue
+		}
 		schemaTypeID := SchemaTypeID(pathElement.schemaNode)
 		stringPath += fmt.Sprintf("%s%s<%s>", PathSeparator, pathElement.key, schemaTypeID)
 	}
@@ -139,9 +144,13 @@ func (path Path) Last() *PathElement {
 	return nil
 }
 
-// Push a new PathElement in Path
+// Push a new PathElement in Path.
+// The elements are copied, so the Path(s) pushed from the same Path never share the new element
+// (for example when the items of a list are processed concurrently)
 func (path Path) Push(schemaNode *SchemaNode, data Data, key string) *Path {
-	path.elements = append(path.elements, *NewPathElement(schemaNode, data, key))
+	elements := make([]PathElement, len(path.elements), len(path.elements)+1)
+	copy(elements, path.elements)
+	path.elements = append(elements, *NewPathElement(schemaNode, data, key))
 
 	return &path
 }
@@ -0,0 +1,95 @@
+package pongo
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ProcessOptions configure how a schema processes the data, see ProcessWithOptions.
+// The options are carried by the DataPointer, so they are available to every SchemaType of the schema
+//   - Context: when the Context is done, the processing of lists and objects stops with the Context error
+//   - Workers: if greater than 1, the items of lists and objects are processed concurrently by Workers goroutines;
+//     the output and the errors order is the same of the sequential processing.
+//     ListType.Workers and ObjectType.Workers override it for a single SchemaType
+//   - FailFast: stop processing the items of lists and objects after the first error
+//   - Clock: the current time used by the relative bounds of DatetimeType and by the now() of ExprType,
+//     time.Now if nil
+type ProcessOptions struct {
+	Context  context.Context
+	Workers  int
+	FailFast bool
+	Clock    func() time.Time
+}
+
+func (o ProcessOptions) now() time.Time {
+	if o.Clock == nil {
+		return time.Now()
+	}
+	return o.Clock()
+}
+
+func (o ProcessOptions) context() context.Context {
+	if o.Context == nil {
+		return context.Background()
+	}
+	return o.Context
+}
+
+// processChildren call process for every index in [0, n) and return the results and the errors by index,
+// concurrently if workers is greater than 1. The returned error is not nil only if the Context is done;
+// if FailFast is set, some of the children may not be processed after the first error
+func processChildren(options ProcessOptions, workers int, n int, process func(i int) (Data, error)) ([]Data, []error, error) {
+	results := make([]Data, n)
+	errs := make([]error, n)
+	ctx := options.context()
+
+	if workers <= 1 || n <= 1 {
+		for i := 0; i < n; i++ {
+			if err := ctx.Err(); err != nil {
+				return nil, nil, err
+			}
+			results[i], errs[i] = process(i)
+			if errs[i] != nil && options.FailFast {
+				break
+			}
+		}
+		return results, errs, nil
+	}
+
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+	indexes := make(chan int)
+
+	for w := 0; w < workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = process(i)
+				if errs[i] != nil && options.FailFast {
+					failed.Store(true)
+				}
+			}
+		}()
+	}
+
+	var err error
+feed:
+	for i := 0; i < n && !failed.Load(); i++ {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			break feed
+		case indexes <- i:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err != nil {
+		return nil, nil, err
+	}
+	return results, errs, nil
+}
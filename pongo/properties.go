@@ -3,7 +3,9 @@ package pongo
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -27,6 +29,9 @@ func (a *ActionProperty[T]) SetAction(action SchemaAction, value T) *ActionPrope
 	if a == nil {
 		a = &ActionProperty[T]{}
 	}
+	if a.Actions == nil {
+		a.Actions = map[SchemaAction]T{}
+	}
 	a.Actions[action] = value
 	return a
 }
@@ -258,8 +263,11 @@ func (m *NumberProperty[T]) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// TimeProperty is a time.Time property, either a fixed instant marshalled as an RFC 3339 string
+// or an ISO 8601 duration relative to the current time (e.g. "-P90D"), marshalled as {"relative": "-P90D"}
 type TimeProperty struct {
-	t *time.Time
+	t        *time.Time
+	relative *string
 }
 
 func (b *TimeProperty) Set(i time.Time) *TimeProperty {
@@ -267,6 +275,18 @@ func (b *TimeProperty) Set(i time.Time) *TimeProperty {
 		b = &TimeProperty{}
 	}
 	b.t = &i
+	b.relative = nil
+
+	return b
+}
+
+// SetRelative set the property to the current time shifted by period, an ISO 8601 duration optionally negative
+func (b *TimeProperty) SetRelative(period string) *TimeProperty {
+	if b == nil {
+		b = &TimeProperty{}
+	}
+	b.t = nil
+	b.relative = &period
 
 	return b
 }
@@ -276,16 +296,58 @@ func (b *TimeProperty) Unset() {
 		return
 	}
 	b.t = nil
+	b.relative = nil
 }
 
+// Get return the time of the property, a relative one is evaluated with time.Now
 func (b *TimeProperty) Get() (i time.Time, ok bool) {
-	if b == nil || b.t == nil {
-		return time.Time{}, false
+	i, ok, err := b.GetAt(time.Now())
+	return i, ok && err == nil
+}
+
+// GetRelative return the ISO 8601 duration of a relative property
+func (b *TimeProperty) GetRelative() (period string, ok bool) {
+	if b == nil || b.relative == nil {
+		return "", false
+	}
+	return *b.relative, true
+}
+
+// GetAt return the time of the property, a relative one is evaluated shifting now.
+// An error is returned if the relative duration is not a valid ISO 8601 duration
+func (b *TimeProperty) GetAt(now time.Time) (i time.Time, ok bool, err error) {
+	if b == nil {
+		return time.Time{}, false, nil
 	}
-	return *b.t, true
+	if b.relative != nil {
+		p, err := parseISOPeriod(*b.relative)
+		if err != nil {
+			return time.Time{}, true, err
+		}
+		return p.AddTo(now), true, nil
+	}
+	if b.t == nil {
+		return time.Time{}, false, nil
+	}
+	return *b.t, true, nil
+}
+
+// describe return i, the value of the property evaluated with GetAt, adding the relative duration if any
+func (b *TimeProperty) describe(i time.Time) string {
+	if period, ok := b.GetRelative(); ok {
+		return fmt.Sprintf("%s, %s from now", i.Format(time.RFC3339Nano), period)
+	}
+	return i.Format(time.RFC3339Nano)
+}
+
+type timePropertyRelative struct {
+	Relative string `json:"relative"`
 }
 
 func (b TimeProperty) MarshalJSON() ([]byte, error) {
+	if b.relative != nil {
+		return json.Marshal(timePropertyRelative{Relative: *b.relative})
+	}
 	if b.t != nil {
 		return json.Marshal(b.t.Format(time.RFC3339Nano))
 	}
@@ -293,24 +355,276 @@ func (b TimeProperty) MarshalJSON() ([]byte, error) {
 }
 
 func (b *TimeProperty) UnmarshalJSON(bytes []byte) error {
+	if trimmed := strings.TrimSpace(string(bytes)); strings.HasPrefix(trimmed, "{") {
+		var r timePropertyRelative
+		if err := json.Unmarshal(bytes, &r); err != nil {
+			return fmt.Errorf("error decoding TimeProperty, got error: %w", err)
+		}
+		if _, err := parseISOPeriod(r.Relative); err != nil {
+			return fmt.Errorf("error decoding TimeProperty, got error: %w", err)
+		}
+		b.t, b.relative = nil, &r.Relative
+		return nil
+	}
+
 	var d *string
 	err := json.Unmarshal(bytes, &d)
 	if err != nil {
-		return fmt.Errorf("error decoding NumberProperty, got error: %w", err)
+		return fmt.Errorf("error decoding TimeProperty, got error: %w", err)
 	}
 
 	if d == nil {
-		b.t = nil
+		b.t, b.relative = nil, nil
 		return nil
 	}
 
 	var t time.Time
 	t, err = time.Parse(time.RFC3339Nano, *d)
 	if err != nil {
-		return fmt.Errorf("error decoding NumberProperty, got error: %w", err)
+		return fmt.Errorf("error decoding TimeProperty, got error: %w", err)
+	}
+
+	b.t, b.relative = &t, nil
+
+	return nil
+}
+
+// LocationProperty is a *time.Location property, marshalled as its name (e.g. "UTC" or "Europe/Rome")
+// and unmarshalled with time.LoadLocation
+type LocationProperty struct {
+	l *time.Location
+}
+
+func (b *LocationProperty) Set(l *time.Location) *LocationProperty {
+	if b == nil {
+		b = &LocationProperty{}
 	}
+	b.l = l
+
+	return b
+}
+
+func (b *LocationProperty) Unset() {
+	if b == nil {
+		return
+	}
+	b.l = nil
+}
+
+func (b *LocationProperty) Get() (l *time.Location, ok bool) {
+	if b == nil || b.l == nil {
+		return nil, false
+	}
+	return b.l, true
+}
+
+func (b LocationProperty) MarshalJSON() ([]byte, error) {
+	if b.l != nil {
+		return json.Marshal(b.l.String())
+	}
+	return json.Marshal(nil)
+}
+
+func (b *LocationProperty) UnmarshalJSON(bytes []byte) error {
+	var d *string
+	err := json.Unmarshal(bytes, &d)
+	if err != nil {
+		return fmt.Errorf("error decoding LocationProperty, got error: %w", err)
+	}
+
+	if d == nil {
+		b.l = nil
+		return nil
+	}
+
+	l, err := time.LoadLocation(*d)
+	if err != nil {
+		return fmt.Errorf("error decoding LocationProperty, got error: %w", err)
+	}
+
+	b.l = l
+
+	return nil
+}
+
+// DurationProperty is a time.Duration property, marshalled as a Go duration string (e.g. "1h30m0s")
+// and unmarshalled from a Go or an ISO 8601 duration string (e.g. "PT1H30M")
+type DurationProperty struct {
+	d *time.Duration
+}
+
+func (p *DurationProperty) Set(d time.Duration) *DurationProperty {
+	if p == nil {
+		p = &DurationProperty{}
+	}
+	p.d = &d
+
+	return p
+}
+
+func (p *DurationProperty) Unset() {
+	if p != nil {
+		p.d = nil
+	}
+}
 
-	b.t = &t
+func (p *DurationProperty) Get() (d time.Duration, ok bool) {
+	if p == nil || p.d == nil {
+		return 0, false
+	}
+	return *p.d, true
+}
+
+func (p DurationProperty) MarshalJSON() ([]byte, error) {
+	if p.d != nil {
+		return json.Marshal(p.d.String())
+	}
+	return json.Marshal(nil)
+}
+
+func (p *DurationProperty) UnmarshalJSON(bytes []byte) error {
+	var s *string
+	err := json.Unmarshal(bytes, &s)
+	if err != nil {
+		return fmt.Errorf("error decoding DurationProperty, got error: %w", err)
+	}
+
+	if s == nil {
+		p.d = nil
+		return nil
+	}
+
+	d, err := parseDuration(*s)
+	if err != nil {
+		return fmt.Errorf("error decoding DurationProperty, got error: %w", err)
+	}
+
+	p.d = &d
+
+	return nil
+}
+
+// DecimalProperty is a *big.Rat property, marshalled as a decimal string to keep its precision
+// and unmarshalled from a decimal string or number
+type DecimalProperty struct {
+	r *big.Rat
+}
+
+// Set set the property to a copy of r, a nil r unsets the property
+func (b *DecimalProperty) Set(r *big.Rat) *DecimalProperty {
+	if r == nil {
+		b.Unset()
+		return b
+	}
+	if b == nil {
+		b = &DecimalProperty{}
+	}
+	b.r = new(big.Rat).Set(r)
+
+	return b
+}
+
+func (b *DecimalProperty) Unset() {
+	if b == nil {
+		return
+	}
+	b.r = nil
+}
+
+func (b *DecimalProperty) Get() (r *big.Rat, ok bool) {
+	if b == nil || b.r == nil {
+		return nil, false
+	}
+	return new(big.Rat).Set(b.r), true
+}
+
+func (b DecimalProperty) MarshalJSON() ([]byte, error) {
+	if b.r == nil {
+		return json.Marshal(nil)
+	}
+	if _, finite := decimalScale(b.r); !finite {
+		return nil, fmt.Errorf("error encoding DecimalProperty: %s has no finite decimal representation", b.r)
+	}
+	return json.Marshal(formatDecimal(b.r, -1))
+}
+
+func (b *DecimalProperty) UnmarshalJSON(bytes []byte) error {
+	var n *json.Number
+	if err := json.Unmarshal(bytes, &n); err != nil {
+		return fmt.Errorf("error decoding DecimalProperty, got error: %w", err)
+	}
+
+	if n == nil {
+		b.r = nil
+		return nil
+	}
+
+	r, err := parseDecimal(n.String())
+	if err != nil {
+		return fmt.Errorf("error decoding DecimalProperty, got error: %w", err)
+	}
+	b.r = r
+
+	return nil
+}
+
+// BigIntProperty is a *big.Int property, marshalled as a decimal string to keep its precision
+// and unmarshalled from an integer string or number
+type BigIntProperty struct {
+	i *big.Int
+}
+
+// Set set the property to a copy of i, a nil i unsets the property
+func (b *BigIntProperty) Set(i *big.Int) *BigIntProperty {
+	if i == nil {
+		b.Unset()
+		return b
+	}
+	if b == nil {
+		b = &BigIntProperty{}
+	}
+	b.i = new(big.Int).Set(i)
+
+	return b
+}
+
+func (b *BigIntProperty) Unset() {
+	if b == nil {
+		return
+	}
+	b.i = nil
+}
+
+func (b *BigIntProperty) Get() (i *big.Int, ok bool) {
+	if b == nil || b.i == nil {
+		return nil, false
+	}
+	return new(big.Int).Set(b.i), true
+}
+
+func (b BigIntProperty) MarshalJSON() ([]byte, error) {
+	if b.i == nil {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(b.i.String())
+}
+
+func (b *BigIntProperty) UnmarshalJSON(bytes []byte) error {
+	var n *json.Number
+	if err := json.Unmarshal(bytes, &n); err != nil {
+		return fmt.Errorf("error decoding BigIntProperty, got error: %w", err)
+	}
+
+	if n == nil {
+		b.i = nil
+		return nil
+	}
+
+	i, err := parseBigInt(n.String())
+	if err != nil {
+		return fmt.Errorf("error decoding BigIntProperty, got error: %w", err)
+	}
+	b.i = i
 
 	return nil
 }
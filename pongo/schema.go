@@ -27,7 +27,13 @@ type SchemaNode struct {
 	SchemaType
 
 	Metadata *Metadata
-	rawJSON  []byte
+	// Validators are run in order on the data processed by SchemaType, see SchemaValidator
+	Validators []*SchemaValidator
+	// Sensitive, if set, redacts the data in the errors and masks it on SchemaActionSerialize
+	Sensitive *Sensitive
+	rawJSON   []byte
+	// marshalTypeID, if set, is the $type marshalled instead of SchemaTypeID, see marshalMapperSchemaNode
+	marshalTypeID string
 }
 
 func NewEmptySchema() *SchemaNode {
@@ -59,17 +65,67 @@ func (s *SchemaNode) SetType(schemaType SchemaType) {
 	s.SchemaType = schemaType
 }
 
+// AddValidators append validators to the SchemaNode Validators
+func (s *SchemaNode) AddValidators(validators ...*SchemaValidator) *SchemaNode {
+	s.Validators = append(s.Validators, validators...)
+	return s
+}
+
+// SetSensitive marks the SchemaNode data as sensitive, the data is masked with mask on SchemaActionSerialize
+func (s *SchemaNode) SetSensitive(mask MaskMode) *SchemaNode {
+	s.Sensitive = &Sensitive{Mask: mask}
+	return s
+}
+
 func (s SchemaNode) Process(action SchemaAction, data *DataPointer) (Data, error) {
-	if s.SchemaType != nil {
-		return s.SchemaType.Process(action, data)
+	processed, err := s.process(action, data)
+	if s.Sensitive == nil {
+		return processed, err
+	}
+	if err != nil {
+		return nil, sensitiveErrors(data.Path(), err)
+	}
+
+	if action == SchemaActionSerialize {
+		if processed, err = s.Sensitive.mask(processed); err != nil {
+			return nil, NewSchemaErrorWithError(data.Path(), SensitiveError{err: err})
+		}
+	}
+	return processed, nil
+}
+
+func (s SchemaNode) process(action SchemaAction, data *DataPointer) (Data, error) {
+	if s.SchemaType == nil {
+		return nil, ErrNoSchemaTypeSet
+	}
+
+	processed, err := s.SchemaType.Process(action, data)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, ErrNoSchemaTypeSet
+	for _, validator := range s.Validators {
+		if validator == nil {
+			return nil, NewSchemaErrorWithError(data.Path(), fmt.Errorf("schema does not validate: %s has a nil validator", data.Path()))
+		}
+		processed, err = validator.Process(action, processed, data)
+		if err != nil {
+			if _, ok := err.(*SchemaError); ok {
+				return nil, err
+			}
+			return nil, NewSchemaErrorWithError(data.Path(), fmt.Errorf("schema does not validate: %s validator %s failed: %w", data.Path(), validator.Name, err))
+		}
+	}
+
+	return processed, nil
 }
 
 func (s *SchemaNode) MarshalJSON() ([]byte, error) {
 	schemaType := s.Type()
-	k := SchemaTypeID(schemaType)
+	k := s.marshalTypeID
+	if k == "" {
+		k = SchemaTypeID(schemaType)
+	}
 	var marshalled marshalSchemaType
 	var schemaTypeJSON json.RawMessage
 
@@ -86,6 +142,8 @@ func (s *SchemaNode) MarshalJSON() ([]byte, error) {
 	}
 
 	marshalled.Metadata = s.Metadata
+	marshalled.Validators = s.Validators
+	marshalled.Sensitive = s.Sensitive
 
 	return json.Marshal(marshalled)
 }
@@ -95,43 +153,62 @@ func (s *SchemaNode) UnmarshalJSON(jsonSchema []byte) error {
 	return nil
 }
 
-func (s *SchemaNode) unmarshalRawJSON(mapper *PongoSchemaUnmarshalMapper) (err error) {
+func (s *SchemaNode) unmarshalRawJSON(ctx *pongoSchemaUnmarshalContext) (err error) {
 	defer s.cleanRawJSON()
 	var unmarshal marshalSchemaType
 
-	err = json.Unmarshal(s.rawJSON, &unmarshal)
+	err = unmarshalSchemaNodeJSON(s.rawJSON, &unmarshal)
 
 	if err != nil {
-		return err
+		return ctx.wrapError(s.rawJSON, documentPositionNode, err)
 	}
 
 	s.Metadata = unmarshal.Metadata
 
+	if unmarshal.Sensitive != nil {
+		if err = unmarshal.Sensitive.validate(); err != nil {
+			return ctx.wrapError(s.rawJSON, documentPositionNode, fmt.Errorf("cannot unmarshall $sensitive in %s: %w", s.rawJSON, err))
+		}
+	}
+	s.Sensitive = unmarshal.Sensitive
+
 	if unmarshal.Type == nil {
-		return fmt.Errorf("cannot unmarshal PongoSchema, no $type set in %s", s.rawJSON)
+		return ctx.wrapError(s.rawJSON, documentPositionNode, fmt.Errorf("cannot unmarshal PongoSchema, no $type set in %s", s.rawJSON))
 	}
 
-	schemaType := mapper.Get(*unmarshal.Type)
+	schemaType := ctx.mapper.Get(*unmarshal.Type)
 	if schemaType == nil {
-		return fmt.Errorf("cannot unmarshall SchemaType element: SchemaType ID %s not found in PongoSchemaUnmarshalMapper", *unmarshal.Type)
+		return ctx.wrapError(s.rawJSON, documentPositionType, fmt.Errorf("cannot unmarshall SchemaType element: SchemaType ID %s not found in PongoSchemaUnmarshalMapper", *unmarshal.Type))
 	}
 
 	if unmarshal.Body != nil {
 		err = json.Unmarshal(*unmarshal.Body, schemaType)
 		if err != nil {
-			return fmt.Errorf("cannot unmarshall $body in %v: %w", s.rawJSON, err)
+			return ctx.wrapError(s.rawJSON, documentPositionBody, fmt.Errorf("cannot unmarshall $body in %s: %w", s.rawJSON, err))
 		}
 	}
 
 	s.SetType(schemaType)
 
+	s.Validators = nil
+	for _, v := range unmarshal.Validators {
+		if v == nil {
+			return ctx.wrapError(s.rawJSON, documentPositionNode, fmt.Errorf("cannot unmarshall $validators in %s: null validator", s.rawJSON))
+		}
+		validator, err := ctx.mapper.Validator(v.Name, v.Args)
+		if err != nil {
+			return ctx.wrapError(s.rawJSON, documentPositionNode, fmt.Errorf("cannot unmarshall $validators in %s: %w", s.rawJSON, err))
+		}
+		s.Validators = append(s.Validators, validator)
+	}
+
 	children, err := s.Children()
 	if err != nil {
 		return err
 	}
 
 	for _, c := range children {
-		err = c.unmarshalRawJSON(mapper)
+		err = c.unmarshalRawJSON(ctx)
 		c.cleanRawJSON()
 		if err != nil {
 			return err
@@ -254,7 +331,10 @@ type CustomSchemaTypeID interface {
 }
 
 // SchemaTypeID generate a string that identify the s SchemaType.
-// This string is used, for example, for the Pongo Schema Marshaling
+// This string is used, for example, for the Pongo Schema Marshaling.
+// The ID is, in order of priority, the one returned by CustomSchemaTypeID, the one used to register the type
+// with Register in the global PongoSchemaUnmarshalMapper or the name of the type, including the package name.
+// The types registered in another mapper are marshalled with their ID by MarshalPongoSchemaWithMapper
 func SchemaTypeID(s SchemaType) string {
 	// we must remove the first char of type, which is always a `*`
 	// since SchemaType is an interface
@@ -265,6 +345,9 @@ func SchemaTypeID(s SchemaType) string {
 	if customSchemaTypeID, ok := s.(CustomSchemaTypeID); ok {
 		return customSchemaTypeID.SchemaTypeID()
 	}
+	if id, ok := globalMapper().typeID(reflect.TypeOf(s)); ok {
+		return id
+	}
 
 	return reflect.TypeOf(s).String()[1:]
 }
@@ -291,9 +374,14 @@ func Serialize(schema SchemaType, data Data) (Data, error) {
 // Process is wrapper for SchemaNode.Process that automatically
 // transforms Data into a DataPointer
 func Process(schema SchemaType, action SchemaAction, data Data) (Data, error) {
+	return ProcessWithOptions(schema, action, data, ProcessOptions{})
+}
+
+// ProcessWithOptions works as Process, but the schema is processed with the given ProcessOptions
+func ProcessWithOptions(schema SchemaType, action SchemaAction, data Data, options ProcessOptions) (Data, error) {
 	schemaNode, ok := schema.(*SchemaNode)
 	if !ok {
 		schemaNode = Schema(schema)
 	}
-	return schema.Process(action, NewDataPointer(schemaNode, data))
+	return schema.Process(action, NewDataPointerWithOptions(schemaNode, data, options))
 }
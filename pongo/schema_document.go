@@ -0,0 +1,195 @@
+package pongo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+/* The pongo schema document is handled, before the SchemaNode unmarshalling, as a generic JSON tree.
+The tree is made of nil, bool, json.Number, string, []interface{} and *documentObject values:
+documentObject keeps the keys in the same order as in the document, so a document can be decoded,
+transformed and encoded again without altering the order chosen by the author.
+*/
+
+// documentObject is a JSON object which preserves the keys order
+type documentObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newDocumentObject() *documentObject {
+	return &documentObject{values: map[string]interface{}{}}
+}
+
+func (o *documentObject) Get(key string) (value interface{}, ok bool) {
+	value, ok = o.values[key]
+	return
+}
+
+// Set add or replace a key, new keys are appended at the end of the object
+func (o *documentObject) Set(key string, value interface{}) *documentObject {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+	return o
+}
+
+func (o *documentObject) Delete(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+func (o *documentObject) Keys() []string {
+	return o.keys
+}
+
+func (o *documentObject) Len() int {
+	return len(o.keys)
+}
+
+func (o *documentObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// decodeDocument decode a JSON document into a generic JSON tree
+func decodeDocument(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	value, err := decodeDocumentValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = decoder.Token(); err != io.EOF {
+		return nil, errors.New("invalid character after top-level value")
+	}
+
+	return value, nil
+}
+
+func decodeDocumentValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			object := newDocumentObject()
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, ok := keyToken.(string)
+				if !ok {
+					return nil, fmt.Errorf("expected an object key, got %v", keyToken)
+				}
+				value, err := decodeDocumentValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				object.Set(key, value)
+			}
+			// consume the closing delimiter
+			if _, err = decoder.Token(); err != nil {
+				return nil, err
+			}
+			return object, nil
+		case '[':
+			list := []interface{}{}
+			for decoder.More() {
+				value, err := decodeDocumentValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, value)
+			}
+			if _, err = decoder.Token(); err != nil {
+				return nil, err
+			}
+			return list, nil
+		}
+		return nil, fmt.Errorf("unexpected delimiter %s", t)
+	default:
+		// bool, json.Number, string or nil
+		return t, nil
+	}
+}
+
+// encodeDocument encode a generic JSON tree in a compact JSON.
+// The same tree is always encoded in the same way, so the encoding of a subtree
+// is also a substring of the encoding of the whole tree
+func encodeDocument(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// jsonObjectKeys return the keys of a JSON object in the same order as in the document,
+// nil is returned if rawJSON is empty or null
+func jsonObjectKeys(rawJSON []byte) ([]string, error) {
+	if len(bytes.TrimSpace(rawJSON)) == 0 || string(bytes.TrimSpace(rawJSON)) == "null" {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(rawJSON))
+	if t, err := decoder.Token(); err != nil {
+		return nil, err
+	} else if t != json.Delim('{') {
+		return nil, fmt.Errorf("expected a JSON object, got %v", t)
+	}
+
+	var keys []string
+	for decoder.More() {
+		t, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, t.(string))
+
+		var value json.RawMessage
+		if err = decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
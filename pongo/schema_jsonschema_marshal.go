@@ -7,6 +7,14 @@ import (
 
 const jsonSchemaDraft07Schema string = "http://json-schema.org/draft-07/schema#"
 
+// JSONSchemaDraft is the JSON Schema draft used by MarshalJSONSchemaWithDraft, identified by its meta-schema URI
+type JSONSchemaDraft string
+
+const (
+	JSONSchemaDraft07                     = JSONSchemaDraft(jsonSchemaDraft07Schema)
+	JSONSchemaDraft202012 JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+)
+
 // JSONSchemaMarshaler is a SchemaType which can be marshaled into a jsonschema
 type JSONSchemaMarshaler interface {
 	SchemaType
@@ -14,11 +22,24 @@ type JSONSchemaMarshaler interface {
 	MarshalJSONSchema(action SchemaAction) ([]byte, error)
 }
 
+// jsonSchemaDraftMarshaler is a JSONSchemaMarshaler whose export depends on the JSON Schema draft,
+// because it has draft-specific keywords or children which may have them
+type jsonSchemaDraftMarshaler interface {
+	JSONSchemaMarshaler
+
+	marshalJSONSchemaDraft(action SchemaAction, draft JSONSchemaDraft) ([]byte, error)
+}
+
 func MarshalJSONSchemaWithMetadata(schema *SchemaNode, action SchemaAction) ([]byte, error) {
+	return marshalJSONSchemaDocument(schema, action, JSONSchemaDraft07)
+}
+
+// marshalJSONSchemaDocument export the schema in draft with its $id, the $schema is always the draft-07 one
+func marshalJSONSchemaDocument(schema *SchemaNode, action SchemaAction, draft JSONSchemaDraft) ([]byte, error) {
 	var jsonObject map[string]json.RawMessage
 	var metadata = schema.Metadata
 
-	var jsonBytes, err = MarshalJSONSchema(schema, action)
+	var jsonBytes, err = marshalChildJSONSchema(schema, action, draft)
 	if err != nil {
 		return nil, err
 	}
@@ -49,3 +70,79 @@ func MarshalJSONSchema(schema *SchemaNode, action SchemaAction) ([]byte, error)
 
 	return schemaType.MarshalJSONSchema(action)
 }
+
+// marshalChildJSONSchema works as MarshalJSONSchema, passing draft to the SchemaType(s) implementing jsonSchemaDraftMarshaler
+func marshalChildJSONSchema(schema *SchemaNode, action SchemaAction, draft JSONSchemaDraft) ([]byte, error) {
+	if schemaType, ok := schema.Type().(jsonSchemaDraftMarshaler); ok {
+		return schemaType.marshalJSONSchemaDraft(action, draft)
+	}
+	return MarshalJSONSchema(schema, action)
+}
+
+// MarshalJSONSchemaWithDraft works as MarshalJSONSchemaWithMetadata, but the JSON Schema is exported in the given draft.
+// The SchemaType(s) export draft-07 keywords, which are then rewritten in the target draft
+// (for example the "items" array and "additionalItems" become "prefixItems" and "items" in draft 2020-12),
+// and the keywords missing in draft-07, such as "minContains", only when exporting in draft 2020-12
+func MarshalJSONSchemaWithDraft(schema *SchemaNode, action SchemaAction, draft JSONSchemaDraft) ([]byte, error) {
+	switch draft {
+	case JSONSchemaDraft07:
+		return marshalJSONSchemaDocument(schema, action, draft)
+	case JSONSchemaDraft202012:
+		jsonBytes, err := marshalJSONSchemaDocument(schema, action, draft)
+		if err != nil {
+			return nil, err
+		}
+		document, err := decodeDocument(jsonBytes)
+		if err != nil {
+			return nil, err
+		}
+		jsonSchemaToDraft202012(document)
+		document.(*documentObject).Set("$schema", string(draft))
+		return encodeDocument(document)
+	}
+
+	return nil, fmt.Errorf("unsupported JSON Schema draft %s", draft)
+}
+
+// jsonSchemaToDraft202012 rewrite a draft-07 JSON Schema, decoded as a generic JSON tree, in draft 2020-12
+func jsonSchemaToDraft202012(value interface{}) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			jsonSchemaToDraft202012(item)
+		}
+	case *documentObject:
+		for _, key := range v.Keys() {
+			switch key {
+			case "const", "enum", "default", "examples":
+				// values, not schemas
+				continue
+			case "properties", "patternProperties", "definitions", "$defs":
+				// the keys are names, not keywords
+				if names, ok := v.values[key].(*documentObject); ok {
+					for _, name := range names.Keys() {
+						jsonSchemaToDraft202012(names.values[name])
+					}
+				}
+				continue
+			}
+			jsonSchemaToDraft202012(v.values[key])
+		}
+
+		if _, ok := v.values["items"].([]interface{}); !ok {
+			return
+		}
+		rewritten := newDocumentObject()
+		for _, key := range v.Keys() {
+			switch key {
+			case "items":
+				rewritten.Set("prefixItems", v.values[key])
+			case "additionalItems":
+				rewritten.Set("items", v.values[key])
+			default:
+				rewritten.Set(key, v.values[key])
+			}
+		}
+		*v = *rewritten
+	}
+}
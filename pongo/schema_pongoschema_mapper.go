@@ -0,0 +1,282 @@
+package pongo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+var ErrInvalidSchemaTypeID = errors.New("invalid SchemaType ID")
+var ErrSchemaTypeAlreadyRegistered = errors.New("SchemaType ID already registered")
+var ErrSchemaTypeNotRegistered = errors.New("SchemaType ID not registered")
+
+// schemaTypeIDRegexp matches both the plain SchemaType IDs (such as "string" or "mypackage.MyType")
+// and the namespaced ones (such as "acme.io/money"), where the namespace is a domain name owned by the SchemaType author
+var schemaTypeIDRegexp = regexp.MustCompile(`^(([a-z0-9-]+(\.[a-z0-9-]+)+)(/[A-Za-z0-9_.-]+)*/)?[A-Za-z0-9_.-]+$`)
+
+// ValidateSchemaTypeID checks that id can be used to register a SchemaType
+func ValidateSchemaTypeID(id string) error {
+	if !schemaTypeIDRegexp.MatchString(id) {
+		return fmt.Errorf("%w %q, expected a name such as \"money\" or a namespaced name such as \"acme.io/money\"", ErrInvalidSchemaTypeID, id)
+	}
+	return nil
+}
+
+type SchemaFactory func() SchemaType
+
+// RegisteredSchemaType describe a SchemaType registered in a PongoSchemaUnmarshalMapper
+type RegisteredSchemaType struct {
+	ID      string
+	Aliases []string
+	Factory SchemaFactory
+}
+
+// PongoSchemaUnmarshalMapper maps the SchemaType IDs to the factories used to unmarshal them.
+// A PongoSchemaUnmarshalMapper is safe for concurrent use
+type PongoSchemaUnmarshalMapper struct { // revive:disable-line
+	mutex             sync.RWMutex
+	schemaElementsMap map[string]SchemaFactory
+	// aliases maps an alias to the SchemaType ID
+	aliases map[string]string
+	// typeIDs maps the types registered with Register to their ID, see SchemaTypeID
+	typeIDs map[reflect.Type]string
+	// validators maps the validator names to their factories, see RegisterValidator
+	validators map[string]ValidatorFactory
+}
+
+func NewPongoSchemaUnmarshalMapper() *PongoSchemaUnmarshalMapper {
+	return &PongoSchemaUnmarshalMapper{
+		schemaElementsMap: map[string]SchemaFactory{},
+		aliases:           map[string]string{},
+		typeIDs:           map[reflect.Type]string{},
+		validators:        map[string]ValidatorFactory{},
+	}
+}
+
+// SchemaElements return a copy of the SchemaType IDs to factory map, aliases excluded
+func (p *PongoSchemaUnmarshalMapper) SchemaElements() map[string]SchemaFactory {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	elements := make(map[string]SchemaFactory, len(p.schemaElementsMap))
+	for key, el := range p.schemaElementsMap {
+		elements[key] = el
+	}
+	return elements
+}
+
+// Get return a new SchemaType for the given SchemaType ID or alias, nil if it is not registered
+func (p *PongoSchemaUnmarshalMapper) Get(schemaElementID string) SchemaType {
+	p.mutex.RLock()
+	if id, ok := p.aliases[schemaElementID]; ok {
+		schemaElementID = id
+	}
+	schemaType, ok := p.schemaElementsMap[schemaElementID]
+	p.mutex.RUnlock()
+
+	if !ok {
+		return nil
+	}
+	return schemaType()
+}
+
+// Set register the factory using the SchemaTypeID of the SchemaType it returns,
+// if the SchemaType ID is already registered the factory is replaced
+func (p *PongoSchemaUnmarshalMapper) Set(schema SchemaFactory) *PongoSchemaUnmarshalMapper {
+	id := SchemaTypeID(schema())
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.schemaElementsMap[id] = schema
+
+	return p
+}
+
+// Register the factory with the given SchemaType ID, which should be namespaced for custom types (e.g. "acme.io/money").
+// An error is returned if the ID is invalid or already registered, as ID or as alias.
+// Unless the SchemaType implements CustomSchemaTypeID, once registered in the global PongoSchemaUnmarshalMapper
+// SchemaTypeID will return id for the SchemaType
+func (p *PongoSchemaUnmarshalMapper) Register(id string, schema SchemaFactory) error {
+	if err := ValidateSchemaTypeID(id); err != nil {
+		return err
+	}
+	schemaType := schema()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if err := p.checkAvailable(id); err != nil {
+		return err
+	}
+	p.schemaElementsMap[id] = schema
+	if _, ok := schemaType.(CustomSchemaTypeID); !ok && schemaType != nil {
+		p.typeIDs[reflect.TypeOf(schemaType)] = id
+	}
+
+	return nil
+}
+
+// RegisterAlias register alias as an alternative ID of an already registered SchemaType ID,
+// for example to keep reading the documents written before a SchemaType has been renamed
+func (p *PongoSchemaUnmarshalMapper) RegisterAlias(alias string, id string) error {
+	if err := ValidateSchemaTypeID(alias); err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, ok := p.schemaElementsMap[id]; !ok {
+		return fmt.Errorf("%w: cannot register alias %s of %s", ErrSchemaTypeNotRegistered, alias, id)
+	}
+	if err := p.checkAvailable(alias); err != nil {
+		return err
+	}
+	p.aliases[alias] = id
+
+	return nil
+}
+
+func (p *PongoSchemaUnmarshalMapper) checkAvailable(id string) error {
+	if _, ok := p.schemaElementsMap[id]; ok {
+		return fmt.Errorf("%w: %s", ErrSchemaTypeAlreadyRegistered, id)
+	}
+	if target, ok := p.aliases[id]; ok {
+		return fmt.Errorf("%w: %s is an alias of %s", ErrSchemaTypeAlreadyRegistered, id, target)
+	}
+	return nil
+}
+
+// Types return all the registered SchemaType(s) sorted by ID
+func (p *PongoSchemaUnmarshalMapper) Types() []RegisteredSchemaType {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	var types []RegisteredSchemaType
+	for id, factory := range p.schemaElementsMap {
+		registered := RegisteredSchemaType{ID: id, Factory: factory}
+		for alias, aliasID := range p.aliases {
+			if aliasID == id {
+				registered.Aliases = append(registered.Aliases, alias)
+			}
+		}
+		sort.Strings(registered.Aliases)
+		types = append(types, registered)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].ID < types[j].ID
+	})
+
+	return types
+}
+
+func (p *PongoSchemaUnmarshalMapper) typeID(t reflect.Type) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	id, ok := p.typeIDs[t]
+	return id, ok
+}
+
+// schemaTypeID works as SchemaTypeID, but the ID of a type registered with Register in p takes precedence
+func (p *PongoSchemaUnmarshalMapper) schemaTypeID(s SchemaType) string {
+	switch t := s.(type) {
+	case *SchemaNode:
+		return p.schemaTypeID(t.Type())
+	case *DecoratedType:
+		if t != nil {
+			return p.schemaTypeID(t.OriginalType)
+		}
+	}
+	if id, ok := p.typeID(reflect.TypeOf(s)); ok {
+		return id
+	}
+	return SchemaTypeID(s)
+}
+
+func (p *PongoSchemaUnmarshalMapper) Clone() *PongoSchemaUnmarshalMapper {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	cloneMapper := NewPongoSchemaUnmarshalMapper()
+	for key, el := range p.schemaElementsMap {
+		cloneMapper.schemaElementsMap[key] = el
+	}
+	for key, el := range p.aliases {
+		cloneMapper.aliases[key] = el
+	}
+	for key, el := range p.typeIDs {
+		cloneMapper.typeIDs[key] = el
+	}
+	for key, el := range p.validators {
+		cloneMapper.validators[key] = el
+	}
+
+	return cloneMapper
+}
+
+var globalPongoSchemaUnmarshalMapper = &PongoSchemaUnmarshalMapper{
+	schemaElementsMap: map[string]SchemaFactory{
+		"anyOf":         func() SchemaType { return AnyOf(nil) },
+		"oneOf":         func() SchemaType { return OneOf(nil) },
+		"allOf":         func() SchemaType { return AllOf(nil) },
+		"list":          func() SchemaType { return List(nil) },
+		"object":        func() SchemaType { return Object(nil) },
+		"string":        func() SchemaType { return String() },
+		"int":           func() SchemaType { return Int() },
+		"float64":       func() SchemaType { return Float64() },
+		"bytes":         func() SchemaType { return Bytes() },
+		"bool":          func() SchemaType { return Bool() },
+		"datetime":      func() SchemaType { return Datetime() },
+		"expr":          func() SchemaType { return Expr("") },
+		"discriminator": func() SchemaType { return Discriminator("", nil) },
+		"ifThenElse":    func() SchemaType { return IfThenElse(nil, nil, nil) },
+		"not":           func() SchemaType { return Not(nil) },
+		"tuple":         func() SchemaType { return Tuple() },
+		"encrypted":     func() SchemaType { return Encrypted("", nil) },
+		"duration":      func() SchemaType { return Duration() },
+		"date":          func() SchemaType { return Date() },
+		"timeOfDay":     func() SchemaType { return TimeOfDay() },
+		"decimal":       func() SchemaType { return Decimal() },
+		"bigInt":        func() SchemaType { return BigInt() },
+	},
+	aliases:    map[string]string{},
+	typeIDs:    map[reflect.Type]string{},
+	validators: map[string]ValidatorFactory{},
+}
+
+var globalPongoSchemaUnmarshalMapperMutex sync.RWMutex
+
+func globalMapper() *PongoSchemaUnmarshalMapper {
+	globalPongoSchemaUnmarshalMapperMutex.RLock()
+	defer globalPongoSchemaUnmarshalMapperMutex.RUnlock()
+	return globalPongoSchemaUnmarshalMapper
+}
+
+// GlobalPongoSchemaUnmarshalMapper return a copy of the global PongoSchemaUnmarshalMapper
+func GlobalPongoSchemaUnmarshalMapper() *PongoSchemaUnmarshalMapper {
+	return globalMapper().Clone()
+}
+
+func SetGlobalPongoSchemaUnmarshalMapper(newGlobalMapper *PongoSchemaUnmarshalMapper) {
+	globalPongoSchemaUnmarshalMapperMutex.Lock()
+	defer globalPongoSchemaUnmarshalMapperMutex.Unlock()
+	globalPongoSchemaUnmarshalMapper = newGlobalMapper
+}
+
+// RegisterSchemaType register a SchemaType in the global PongoSchemaUnmarshalMapper, see PongoSchemaUnmarshalMapper.Register
+func RegisterSchemaType(id string, schema SchemaFactory) error {
+	return globalMapper().Register(id, schema)
+}
+
+// RegisterSchemaTypeAlias register an alias in the global PongoSchemaUnmarshalMapper, see PongoSchemaUnmarshalMapper.RegisterAlias
+func RegisterSchemaTypeAlias(alias string, id string) error {
+	return globalMapper().RegisterAlias(alias, id)
+}
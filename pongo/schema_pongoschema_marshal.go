@@ -3,6 +3,9 @@ package pongo
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 )
 
 func MarshalPongoSchema(schema SchemaType) ([]byte, error) {
@@ -10,20 +13,299 @@ func MarshalPongoSchema(schema SchemaType) ([]byte, error) {
 }
 
 func MarshalPongoSchemaWithMetadata(schema SchemaType, metadata *Metadata) ([]byte, error) {
-	d := map[string]interface{}{
-		"$version": "1.0",
-		"$body":    Schema(schema),
+	return MarshalPongoSchemaWithOptions(schema, metadata, PongoSchemaMarshalOptions{})
+}
+
+// MarshalPongoSchemaWithMapper marshal the schema with the $type(s) of the types registered in mapper,
+// see PongoSchemaMarshalOptions.Mapper
+func MarshalPongoSchemaWithMapper(schema SchemaType, mapper *PongoSchemaUnmarshalMapper) ([]byte, error) {
+	return MarshalPongoSchemaWithOptions(schema, nil, PongoSchemaMarshalOptions{Mapper: mapper})
+}
+
+// PongoSchemaMarshalOptions changes how a pongo schema document is marshalled
+// * Compact: emit the SchemaNode(s) in the shorthand form, see unmarshalSchemaNodeJSON
+// * Version: emit the document in an older version of the format, for consumers using an older library version.
+// If empty, PongoSchemaVersion is used
+// * Mapper: emit the $type of the types registered with Register in Mapper with their registered ID,
+// which SchemaTypeID only knows for the types registered in the global mapper. If nil, the global mapper is used
+type PongoSchemaMarshalOptions struct { // revive:disable-line
+	Compact bool
+	Version string
+	Mapper  *PongoSchemaUnmarshalMapper
+}
+
+func MarshalPongoSchemaWithOptions(schema SchemaType, metadata *Metadata, options PongoSchemaMarshalOptions) ([]byte, error) {
+	version := options.Version
+	if version == "" {
+		version = PongoSchemaVersion
 	}
+
+	d := newDocumentObject().Set("$version", version)
 	if metadata != nil {
-		d["$metadata"] = metadata
+		d.Set("$metadata", metadata)
+	}
+
+	node := Schema(schema)
+	if options.Mapper != nil {
+		node = marshalMapperSchemaNode(node, options.Mapper)
+	}
+
+	if !options.Compact && version == PongoSchemaVersion {
+		return encodeDocument(d.Set("$body", node))
+	}
+
+	rawBody, err := encodeDocument(node)
+	if err != nil {
+		return nil, err
+	}
+	body, err := decodeDocument(rawBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != PongoSchemaVersion {
+		err = downgradePongoSchemaDocument(body, version)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.Compact {
+		body = compactSchemaNodeDocument(body)
+	}
+
+	return encodeDocument(d.Set("$body", body))
+}
+
+// marshalMapperSchemaNode return a copy of node, and of the SchemaNode(s) nested in its SchemaType, which is marshalled
+// with the $type resolved by mapper from the type of each SchemaNode, see PongoSchemaMarshalOptions.Mapper.
+// The schema is copied since SchemaNode.MarshalJSON has no other way to receive the mapper
+func marshalMapperSchemaNode(node *SchemaNode, mapper *PongoSchemaUnmarshalMapper) *SchemaNode {
+	if node == nil {
+		return nil
+	}
+
+	c := *node
+	c.marshalTypeID = mapper.schemaTypeID(node.Type())
+	if node.Type() != nil {
+		c.SchemaType = marshalMapperValue(reflect.ValueOf(node.Type()), mapper, map[reflect.Type]bool{}).Interface().(SchemaType)
+	}
+	return &c
+}
+
+var schemaNodePtrType = reflect.TypeOf(&SchemaNode{})
+
+// marshalMapperValue copy v replacing every reachable *SchemaNode with marshalMapperSchemaNode:
+// only the exported struct fields, the slices, the maps and the interfaces holding a SchemaNode are copied,
+// all the other values are shared with v
+func marshalMapperValue(v reflect.Value, mapper *PongoSchemaUnmarshalMapper, seen map[reflect.Type]bool) reflect.Value {
+	if v.Type() == schemaNodePtrType {
+		return reflect.ValueOf(marshalMapperSchemaNode(v.Interface().(*SchemaNode), mapper))
+	}
+	if !containsSchemaNode(v.Type(), seen) {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() || !containsSchemaNode(v.Elem().Type(), seen) {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(marshalMapperValue(v.Elem(), mapper, seen))
+		return c
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(marshalMapperValue(v.Elem(), mapper, seen))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				c.Field(i).Set(marshalMapperValue(v.Field(i), mapper, seen))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(marshalMapperValue(v.Index(i), mapper, seen))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(marshalMapperValue(v.Index(i), mapper, seen))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), marshalMapperValue(iter.Value(), mapper, seen))
+		}
+		return c
+	}
+	return v
+}
+
+// containsSchemaNode return true if a value of type t may reach a *SchemaNode through the values copied by
+// marshalMapperValue; the interfaces may always hold one
+func containsSchemaNode(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if t == schemaNodePtrType || t.Kind() == reflect.Interface {
+		return true
+	}
+	if contains, ok := seen[t]; ok {
+		return contains
+	}
+	// a recursive type is assumed not to contain a SchemaNode until one is found
+	seen[t] = false
+
+	var contains bool
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		contains = containsSchemaNode(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField() && !contains; i++ {
+			contains = t.Field(i).IsExported() && containsSchemaNode(t.Field(i).Type, seen)
+		}
+	}
+	seen[t] = contains
+	return contains
+}
+
+// compactSchemaNodeDocument rewrite, in a generic JSON tree, all the SchemaNode(s) in their shorthand form
+func compactSchemaNodeDocument(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for i := range v {
+			v[i] = compactSchemaNodeDocument(v[i])
+		}
+		return v
+	case *documentObject:
+		for _, key := range v.Keys() {
+			// metadata are plain strings, validators args are opaque and sensitive is a flat object,
+			// they never contain a SchemaNode
+			if key != "$metadata" && key != "$validators" && key != "$sensitive" {
+				v.values[key] = compactSchemaNodeDocument(v.values[key])
+			}
+		}
+	default:
+		return value
+	}
+
+	node := value.(*documentObject)
+	if !isSchemaNodeDocument(node) {
+		return node
+	}
+
+	if node.Len() == 1 {
+		return node.values["$type"]
+	}
+
+	body, ok := node.values["$body"].(*documentObject)
+	if !ok {
+		return node
 	}
-	return json.Marshal(d)
+	for _, key := range body.Keys() {
+		if isSchemaNodeKey(key) {
+			return node
+		}
+	}
+
+	compact := newDocumentObject()
+	for _, key := range node.Keys() {
+		if key != "$body" {
+			compact.Set(key, node.values[key])
+		}
+	}
+	for _, key := range body.Keys() {
+		compact.Set(key, body.values[key])
+	}
+
+	return compact
+}
+
+// isSchemaNodeKey return true if the key is a reserved key of a SchemaNode (e.g. $type) instead of a $body field
+func isSchemaNodeKey(key string) bool {
+	return strings.HasPrefix(key, "$")
+}
+
+// isSchemaNodeDocument return true if the object is a SchemaNode in the full form
+func isSchemaNodeDocument(object *documentObject) bool {
+	if _, ok := object.values["$type"].(string); !ok {
+		return false
+	}
+	for _, key := range object.Keys() {
+		if !isSchemaNodeKey(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// unmarshalSchemaNodeJSON decode the JSON of a SchemaNode, both in the full and in the shorthand form:
+// * a bare string is a shorthand for a SchemaNode with no $body, e.g. "string" means {"$type": "string"}
+// * an object with the $type and the $body fields inlined is a shorthand for the object with the same fields in $body,
+// e.g. {"$type": "int", "min": 1} means {"$type": "int", "$body": {"min": 1}}
+func unmarshalSchemaNodeJSON(rawJSON []byte, unmarshal *marshalSchemaType) error {
+	document, err := decodeDocument(rawJSON)
+	if err != nil {
+		return err
+	}
+
+	switch v := document.(type) {
+	case string:
+		unmarshal.Type = &v
+		return nil
+	case *documentObject:
+		err = json.Unmarshal(rawJSON, unmarshal)
+		if err != nil {
+			return err
+		}
+
+		body := newDocumentObject()
+		for _, key := range v.Keys() {
+			if !isSchemaNodeKey(key) {
+				body.Set(key, v.values[key])
+			}
+		}
+		if body.Len() == 0 {
+			return nil
+		}
+		if unmarshal.Body != nil {
+			return fmt.Errorf("cannot unmarshal PongoSchema, both $body and inline $body fields %v set in %s", body.Keys(), rawJSON)
+		}
+
+		var rawBody json.RawMessage
+		rawBody, err = encodeDocument(body)
+		if err != nil {
+			return err
+		}
+		unmarshal.Body = &rawBody
+		return nil
+	}
+
+	return fmt.Errorf("cannot unmarshal PongoSchema, expected an object or a string, got %s", rawJSON)
 }
 
 type marshalSchemaType struct {
-	Body     *json.RawMessage `json:"$body,omitempty"`
-	Metadata *Metadata        `json:"$metadata,omitempty"`
 	Type     *string          `json:"$type"`
+	Metadata *Metadata        `json:"$metadata,omitempty"`
+	Body     *json.RawMessage `json:"$body,omitempty"`
+
+	Validators []*SchemaValidator `json:"$validators,omitempty"`
+	Sensitive  *Sensitive         `json:"$sensitive,omitempty"`
 }
 
 func UnmarshalPongoSchema(jsonSchema []byte) (schema *SchemaNode, metadata *Metadata, err error) {
@@ -31,102 +313,133 @@ func UnmarshalPongoSchema(jsonSchema []byte) (schema *SchemaNode, metadata *Meta
 }
 
 func UnmarshalPongoSchemaWithMapper(jsonSchema []byte, mapper *PongoSchemaUnmarshalMapper) (schema *SchemaNode, metadata *Metadata, err error) {
-	var root *map[string]json.RawMessage
-
-	err = json.Unmarshal(jsonSchema, &root)
+	document, err := decodeDocument(jsonSchema)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	version, ok := (*root)["$version"]
+	return unmarshalPongoSchemaDocument(document, &pongoSchemaUnmarshalContext{mapper: mapper})
+}
+
+// unmarshalPongoSchemaDocument unmarshal a pongo schema document decoded as a generic JSON tree
+func unmarshalPongoSchemaDocument(document interface{}, ctx *pongoSchemaUnmarshalContext) (schema *SchemaNode, metadata *Metadata, err error) {
+	root, ok := document.(*documentObject)
 	if !ok {
-		return nil, nil, errors.New("expected schema version \"1.0\" in JSON, no version found")
+		return nil, nil, errors.New("expected a pongo schema document object")
 	}
 
-	if string(version) != "\"1.0\"" {
-		return nil, nil, errors.New("expected schema version \"1.0\" in JSON, found " + string(version))
+	version, ok := root.Get("$version")
+	if !ok {
+		return nil, nil, errors.New("expected schema version in JSON, no version found")
 	}
 
-	jsonSchema, ok = (*root)["$body"]
+	versionString, ok := version.(string)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: expected schema version as a string in JSON, found %v", ErrPongoSchemaVersion, version)
+	}
+
+	body, ok := root.Get("$body")
 	if !ok {
 		return nil, nil, errors.New("expected schema body in JSON, no schema found")
 	}
 
+	if versionString != PongoSchemaVersion {
+		err = upgradePongoSchemaDocument(body, versionString)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err = ctx.indexPositions()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	schema = NewEmptySchema()
-	schema.rawJSON = jsonSchema
+	schema.rawJSON, err = encodeDocument(body)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	jsonMetadata, ok := (*root)["$metadata"]
-	if ok {
+	if jsonMetadata, ok := root.Get("$metadata"); ok {
+		var rawMetadata []byte
+		rawMetadata, err = encodeDocument(jsonMetadata)
+		if err != nil {
+			return nil, nil, err
+		}
 		metadata = &Metadata{}
-		err = json.Unmarshal(jsonMetadata, metadata)
+		err = json.Unmarshal(rawMetadata, metadata)
 		if err != nil {
 			return nil, nil, err
 		}
 	}
 
-	return schema, metadata, schema.unmarshalRawJSON(mapper)
+	return schema, metadata, schema.unmarshalRawJSON(ctx)
 }
 
-type SchemaFactory func() SchemaType
-
-type PongoSchemaUnmarshalMapper struct { // revive:disable-line
-	schemaElementsMap map[string]SchemaFactory
+// pongoSchemaUnmarshalContext contains everything needed to unmarshal the SchemaNode(s) of a pongo schema document
+// * mapper resolves the SchemaType(s) from their $type
+// * objectPositions, if set, contains the position of the objects in the source document (e.g. a YAML document)
+// * positions maps the JSON of an object to its position in the source document, it is built from objectPositions
+// with indexPositions once the document has been migrated
+type pongoSchemaUnmarshalContext struct {
+	mapper          *PongoSchemaUnmarshalMapper
+	objectPositions map[*documentObject]documentPosition
+	positions       map[string]documentPosition
 }
 
-func NewPongoSchemaUnmarshalMapper() *PongoSchemaUnmarshalMapper {
-	return &PongoSchemaUnmarshalMapper{
-		map[string]SchemaFactory{},
-	}
-}
+func (c *pongoSchemaUnmarshalContext) indexPositions() error {
+	c.positions = map[string]documentPosition{}
 
-func (p PongoSchemaUnmarshalMapper) SchemaElements() map[string]SchemaFactory {
-	return p.schemaElementsMap
-}
+	for object, position := range c.objectPositions {
+		rawJSON, err := encodeDocument(object)
+		if err != nil {
+			return err
+		}
 
-func (p PongoSchemaUnmarshalMapper) Get(schemaElementID string) SchemaType {
-	schemaType, ok := p.schemaElementsMap[schemaElementID]
-	if !ok {
-		return nil
+		// identical objects have identical errors, so keep the first one in the document
+		if p, ok := c.positions[string(rawJSON)]; ok && p.line < position.line {
+			continue
+		}
+		c.positions[string(rawJSON)] = position
 	}
-	return schemaType()
-}
 
-func (p PongoSchemaUnmarshalMapper) Set(schema SchemaFactory) *PongoSchemaUnmarshalMapper {
-	id := SchemaTypeID(schema())
-	p.schemaElementsMap[id] = schema
+	return nil
+}
 
-	return &p
+// documentPosition contains the lines of a SchemaNode and its $type and $body keys in the source document
+type documentPosition struct {
+	line     int
+	typeLine int
+	bodyLine int
 }
 
-func (p PongoSchemaUnmarshalMapper) Clone() *PongoSchemaUnmarshalMapper {
-	cloneMapper := NewPongoSchemaUnmarshalMapper()
-	for key, el := range p.schemaElementsMap {
-		cloneMapper.schemaElementsMap[key] = el
-	}
+type documentPositionKey int
 
-	return cloneMapper
-}
+const (
+	documentPositionNode documentPositionKey = iota
+	documentPositionType
+	documentPositionBody
+)
 
-var globalPongoSchemaUnmarshalMapper = &PongoSchemaUnmarshalMapper{
-	map[string]SchemaFactory{
-		"anyOf":    func() SchemaType { return AnyOf(nil) },
-		"oneOf":    func() SchemaType { return OneOf(nil) },
-		"allOf":    func() SchemaType { return AllOf(nil) },
-		"list":     func() SchemaType { return List(nil) },
-		"object":   func() SchemaType { return Object(nil) },
-		"string":   func() SchemaType { return String() },
-		"int":      func() SchemaType { return Int() },
-		"float64":  func() SchemaType { return Float64() },
-		"bytes":    func() SchemaType { return Bytes() },
-		"bool":     func() SchemaType { return Bool() },
-		"datetime": func() SchemaType { return Datetime() },
-	},
-}
+// wrapError add to err the position of the SchemaNode rawJSON in the source document, if known
+func (c *pongoSchemaUnmarshalContext) wrapError(rawJSON []byte, key documentPositionKey, err error) error {
+	position, ok := c.positions[string(rawJSON)]
+	if !ok {
+		return err
+	}
 
-func GlobalPongoSchemaUnmarshalMapper() *PongoSchemaUnmarshalMapper {
-	return globalPongoSchemaUnmarshalMapper.Clone()
-}
+	line := position.line
+	switch key {
+	case documentPositionType:
+		if position.typeLine > 0 {
+			line = position.typeLine
+		}
+	case documentPositionBody:
+		if position.bodyLine > 0 {
+			line = position.bodyLine
+		}
+	}
 
-func SetGlobalPongoSchemaUnmarshalMapper(newGlobalMapper *PongoSchemaUnmarshalMapper) {
-	globalPongoSchemaUnmarshalMapper = newGlobalMapper
+	return fmt.Errorf("line %d: %w", line, err)
 }
@@ -0,0 +1,314 @@
+package pongo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PongoSchemaVersion is the latest version of the pongo schema document format,
+// documents are always marshalled in this version unless PongoSchemaMarshalOptions.Version is set
+const PongoSchemaVersion = "1.1"
+
+var ErrPongoSchemaVersion = errors.New("unsupported pongo schema version")
+
+// PongoSchemaMigration is a step which upgrades a pongo schema document from the From version to the To version
+// and downgrades it back. Upgrade and Downgrade are called on every SchemaNode of the document.
+// More than one PongoSchemaMigration can be registered for the same step, they are applied in registration order
+// on upgrade and in reverse order on downgrade
+type PongoSchemaMigration struct { // revive:disable-line
+	From      string
+	To        string
+	Upgrade   MigrationFn
+	Downgrade MigrationFn
+}
+
+// MigrationFn migrates a single SchemaNode of a pongo schema document
+type MigrationFn func(node *MigrationNode) error
+
+// MigrationNode is a SchemaNode of a pongo schema document being migrated.
+// The node is always in the full form (not in the shorthand one), so all the $body fields are in $body
+type MigrationNode struct {
+	object *documentObject
+}
+
+func (n MigrationNode) Type() string {
+	t, _ := n.object.values["$type"].(string)
+	return t
+}
+
+func (n MigrationNode) SetType(schemaTypeID string) {
+	n.object.Set("$type", schemaTypeID)
+}
+
+func (n MigrationNode) body() *documentObject {
+	body, _ := n.object.values["$body"].(*documentObject)
+	return body
+}
+
+// BodyField return the JSON of a $body field
+func (n MigrationNode) BodyField(key string) (json.RawMessage, bool) {
+	body := n.body()
+	if body == nil {
+		return nil, false
+	}
+	value, ok := body.Get(key)
+	if !ok {
+		return nil, false
+	}
+	rawJSON, err := encodeDocument(value)
+	if err != nil {
+		return nil, false
+	}
+	return rawJSON, true
+}
+
+// SetBodyField set a $body field, creating $body if needed
+func (n MigrationNode) SetBodyField(key string, rawJSON json.RawMessage) error {
+	value, err := decodeDocument(rawJSON)
+	if err != nil {
+		return err
+	}
+
+	body := n.body()
+	if body == nil {
+		body = newDocumentObject()
+		n.object.Set("$body", body)
+	}
+	body.Set(key, value)
+	return nil
+}
+
+func (n MigrationNode) DeleteBodyField(key string) {
+	if body := n.body(); body != nil {
+		body.Delete(key)
+	}
+}
+
+// RenameBodyField rename a $body field keeping its position in $body,
+// nothing is done if the field is not set
+func (n MigrationNode) RenameBodyField(oldKey string, newKey string) {
+	body := n.body()
+	if body == nil {
+		return
+	}
+	value, ok := body.Get(oldKey)
+	if !ok {
+		return
+	}
+
+	renamed := newDocumentObject()
+	for _, key := range body.Keys() {
+		if key == oldKey {
+			renamed.Set(newKey, value)
+		} else if key != newKey {
+			renamed.Set(key, body.values[key])
+		}
+	}
+	n.object.Set("$body", renamed)
+}
+
+// RenameBodyFieldMigration return a PongoSchemaMigration which renames a $body field of a SchemaType
+func RenameBodyFieldMigration(from, to, schemaTypeID, oldKey, newKey string) PongoSchemaMigration {
+	return PongoSchemaMigration{
+		From: from,
+		To:   to,
+		Upgrade: func(node *MigrationNode) error {
+			if node.Type() == schemaTypeID {
+				node.RenameBodyField(oldKey, newKey)
+			}
+			return nil
+		},
+		Downgrade: func(node *MigrationNode) error {
+			if node.Type() == schemaTypeID {
+				node.RenameBodyField(newKey, oldKey)
+			}
+			return nil
+		},
+	}
+}
+
+var pongoSchemaVersions = []string{"1.0", "1.1"}
+
+var pongoSchemaMigrations = []PongoSchemaMigration{
+	// 1.1: fix the BytesType "mixLen" typo
+	RenameBodyFieldMigration("1.0", "1.1", "bytes", "mixLen", "minLen"),
+}
+
+var pongoSchemaMigrationsMutex sync.RWMutex
+
+// RegisterPongoSchemaMigration register a PongoSchemaMigration, for example to migrate the $body of a custom SchemaType.
+// From and To must be two consecutive versions supported by the library
+func RegisterPongoSchemaMigration(migration PongoSchemaMigration) error {
+	from := pongoSchemaVersionIndex(migration.From)
+	to := pongoSchemaVersionIndex(migration.To)
+	if from < 0 || to != from+1 {
+		return fmt.Errorf("%w: cannot register a migration from version %s to version %s", ErrPongoSchemaVersion, migration.From, migration.To)
+	}
+
+	pongoSchemaMigrationsMutex.Lock()
+	defer pongoSchemaMigrationsMutex.Unlock()
+	pongoSchemaMigrations = append(pongoSchemaMigrations, migration)
+
+	return nil
+}
+
+func pongoSchemaVersionIndex(version string) int {
+	for i, v := range pongoSchemaVersions {
+		if v == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// comparePongoSchemaVersion compare two "major.minor" versions, returning -1, 0 or 1
+func comparePongoSchemaVersion(a, b string) (int, error) {
+	parse := func(version string) (major int, minor int, err error) {
+		parts := strings.Split(version, ".")
+		if len(parts) != 2 {
+			return 0, 0, fmt.Errorf("%w: invalid version %q, expected \"major.minor\"", ErrPongoSchemaVersion, version)
+		}
+		if major, err = strconv.Atoi(parts[0]); err != nil {
+			return 0, 0, fmt.Errorf("%w: invalid version %q, expected \"major.minor\"", ErrPongoSchemaVersion, version)
+		}
+		if minor, err = strconv.Atoi(parts[1]); err != nil {
+			return 0, 0, fmt.Errorf("%w: invalid version %q, expected \"major.minor\"", ErrPongoSchemaVersion, version)
+		}
+		return major, minor, nil
+	}
+
+	majorA, minorA, err := parse(a)
+	if err != nil {
+		return 0, err
+	}
+	majorB, minorB, err := parse(b)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case majorA < majorB:
+		return -1, nil
+	case majorA > majorB:
+		return 1, nil
+	case minorA < minorB:
+		return -1, nil
+	case minorA > minorB:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// upgradePongoSchemaDocument migrate the body of a document from version to PongoSchemaVersion
+func upgradePongoSchemaDocument(body interface{}, version string) error {
+	cmp, err := comparePongoSchemaVersion(version, PongoSchemaVersion)
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		return fmt.Errorf("%w: schema version %s is newer than the latest supported version %s", ErrPongoSchemaVersion, version, PongoSchemaVersion)
+	}
+
+	index := pongoSchemaVersionIndex(version)
+	if index < 0 {
+		return fmt.Errorf("%w: unknown schema version %s", ErrPongoSchemaVersion, version)
+	}
+
+	pongoSchemaMigrationsMutex.RLock()
+	defer pongoSchemaMigrationsMutex.RUnlock()
+
+	for ; index < len(pongoSchemaVersions)-1; index++ {
+		from, to := pongoSchemaVersions[index], pongoSchemaVersions[index+1]
+		for _, migration := range pongoSchemaMigrations {
+			if migration.From != from || migration.To != to || migration.Upgrade == nil {
+				continue
+			}
+			if err = migratePongoSchemaDocument(body, migration.Upgrade); err != nil {
+				return fmt.Errorf("cannot upgrade schema from version %s to version %s: %w", from, to, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// downgradePongoSchemaDocument migrate the body of a document from PongoSchemaVersion to version
+func downgradePongoSchemaDocument(body interface{}, version string) error {
+	index := pongoSchemaVersionIndex(version)
+	if index < 0 {
+		return fmt.Errorf("%w: unknown schema version %s", ErrPongoSchemaVersion, version)
+	}
+
+	pongoSchemaMigrationsMutex.RLock()
+	defer pongoSchemaMigrationsMutex.RUnlock()
+
+	for i := len(pongoSchemaVersions) - 1; i > index; i-- {
+		from, to := pongoSchemaVersions[i], pongoSchemaVersions[i-1]
+		for j := len(pongoSchemaMigrations) - 1; j >= 0; j-- {
+			migration := pongoSchemaMigrations[j]
+			if migration.From != to || migration.To != from {
+				continue
+			}
+			if migration.Downgrade == nil {
+				return fmt.Errorf("%w: cannot downgrade schema from version %s to version %s, no downgrade available", ErrPongoSchemaVersion, from, to)
+			}
+			if err := migratePongoSchemaDocument(body, migration.Downgrade); err != nil {
+				return fmt.Errorf("cannot downgrade schema from version %s to version %s: %w", from, to, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// migratePongoSchemaDocument call fn on every SchemaNode object in the generic JSON tree,
+// the SchemaNode(s) in the shorthand form with inline $body fields are expanded to the full form
+func migratePongoSchemaDocument(value interface{}, fn MigrationFn) error {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if err := migratePongoSchemaDocument(item, fn); err != nil {
+				return err
+			}
+		}
+	case *documentObject:
+		if _, ok := v.values["$type"].(string); ok {
+			expandSchemaNodeDocument(v)
+			if err := fn(&MigrationNode{object: v}); err != nil {
+				return err
+			}
+		}
+		for _, key := range v.Keys() {
+			if key == "$metadata" || key == "$validators" || key == "$sensitive" {
+				continue
+			}
+			if err := migratePongoSchemaDocument(v.values[key], fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// expandSchemaNodeDocument move the inline $body fields of a SchemaNode object in $body
+func expandSchemaNodeDocument(object *documentObject) {
+	if _, ok := object.values["$body"]; ok {
+		return
+	}
+
+	body := newDocumentObject()
+	for _, key := range object.Keys() {
+		if !isSchemaNodeKey(key) {
+			body.Set(key, object.values[key])
+			object.Delete(key)
+		}
+	}
+	if body.Len() > 0 {
+		object.Set("$body", body)
+	}
+}
@@ -0,0 +1,195 @@
+package pongo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalPongoSchemaYAML marshal the schema in the pongo schema document format, encoded as YAML
+func MarshalPongoSchemaYAML(schema SchemaType) ([]byte, error) {
+	return MarshalPongoSchemaYAMLWithMetadata(schema, nil)
+}
+
+func MarshalPongoSchemaYAMLWithMetadata(schema SchemaType, metadata *Metadata) ([]byte, error) {
+	return MarshalPongoSchemaYAMLWithOptions(schema, metadata, PongoSchemaMarshalOptions{})
+}
+
+func MarshalPongoSchemaYAMLWithOptions(schema SchemaType, metadata *Metadata, options PongoSchemaMarshalOptions) ([]byte, error) {
+	jsonSchema, err := MarshalPongoSchemaWithOptions(schema, metadata, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeDocumentYAML(jsonSchema)
+}
+
+// encodeDocumentYAML convert a JSON document in a YAML one
+func encodeDocumentYAML(jsonDocument []byte) ([]byte, error) {
+	document, err := decodeDocument(jsonDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err = encoder.Encode(documentToYAMLNode(document)); err != nil {
+		return nil, err
+	}
+	if err = encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalPongoSchemaYAML unmarshal a pongo schema document encoded as YAML,
+// the errors on the SchemaNode(s) unmarshalling report the line of the YAML document where the error occurred
+func UnmarshalPongoSchemaYAML(yamlSchema []byte) (schema *SchemaNode, metadata *Metadata, err error) {
+	return UnmarshalPongoSchemaYAMLWithMapper(yamlSchema, GlobalPongoSchemaUnmarshalMapper())
+}
+
+func UnmarshalPongoSchemaYAMLWithMapper(yamlSchema []byte, mapper *PongoSchemaUnmarshalMapper) (schema *SchemaNode, metadata *Metadata, err error) {
+	var root yaml.Node
+
+	err = yaml.Unmarshal(yamlSchema, &root)
+	if err != nil {
+		return nil, nil, err
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil, nil, errors.New("expected a pongo schema document, got an empty YAML document")
+	}
+
+	positions := map[*documentObject]documentPosition{}
+	document, err := yamlNodeToDocument(root.Content[0], positions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx := &pongoSchemaUnmarshalContext{
+		mapper:          mapper,
+		objectPositions: positions,
+	}
+
+	return unmarshalPongoSchemaDocument(document, ctx)
+}
+
+// yamlNodeToDocument convert a YAML node into a generic JSON tree,
+// the position of every object is stored in positions
+func yamlNodeToDocument(node *yaml.Node, positions map[*documentObject]documentPosition) (interface{}, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return yamlNodeToDocument(node.Content[0], positions)
+	case yaml.AliasNode:
+		return yamlNodeToDocument(node.Alias, positions)
+	case yaml.SequenceNode:
+		list := []interface{}{}
+		for _, item := range node.Content {
+			value, err := yamlNodeToDocument(item, positions)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case yaml.MappingNode:
+		object := newDocumentObject()
+		position := documentPosition{line: node.Line}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			if keyNode.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("yaml: line %d: expected a scalar key", keyNode.Line)
+			}
+			value, err := yamlNodeToDocument(valueNode, positions)
+			if err != nil {
+				return nil, err
+			}
+			object.Set(keyNode.Value, value)
+
+			switch keyNode.Value {
+			case "$type":
+				position.typeLine = valueNode.Line
+			case "$body":
+				position.bodyLine = keyNode.Line
+			}
+		}
+		positions[object] = position
+		return object, nil
+	case yaml.ScalarNode:
+		return yamlScalarToDocument(node)
+	}
+
+	return nil, fmt.Errorf("yaml: line %d: unexpected YAML node", node.Line)
+}
+
+func yamlScalarToDocument(node *yaml.Node) (interface{}, error) {
+	switch node.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return nil, err
+		}
+		return b, nil
+	case "!!int":
+		var i int64
+		if err := node.Decode(&i); err != nil {
+			return nil, err
+		}
+		return json.Number(strconv.FormatInt(i, 10)), nil
+	case "!!float":
+		var f float64
+		if err := node.Decode(&f); err != nil {
+			return nil, err
+		}
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, fmt.Errorf("yaml: line %d: %s cannot be represented in a pongo schema", node.Line, node.Value)
+		}
+		return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+	}
+
+	// strings, timestamps and any other scalar are kept as they are written in the document
+	return node.Value, nil
+}
+
+// documentToYAMLNode convert a generic JSON tree in a YAML node
+func documentToYAMLNode(value interface{}) *yaml.Node {
+	switch v := value.(type) {
+	case *documentObject:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, key := range v.Keys() {
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+				documentToYAMLNode(v.values[key]),
+			)
+		}
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range v {
+			node.Content = append(node.Content, documentToYAMLNode(item))
+		}
+		return node
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: v.String()}
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: v.String()}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+	}
+
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+}
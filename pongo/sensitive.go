@@ -0,0 +1,218 @@
+package pongo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// RedactedValue replaces the sensitive data in the errors and in the data masked with MaskRedact
+const RedactedValue = "[REDACTED]"
+
+var ErrInvalidMask = errors.New("invalid mask")
+
+// MaskMode define how the data of a sensitive SchemaNode is masked on SchemaActionSerialize
+type MaskMode string
+
+const (
+	// MaskRedact replaces the data with RedactedValue, it is the default MaskMode
+	MaskRedact MaskMode = "redact"
+	// MaskLast4 keeps only the last 4 characters of the data, e.g. "************1111",
+	// the data with 4 characters or less is fully masked
+	MaskLast4 MaskMode = "last4"
+	// MaskHash replaces the data with the hex encoded SHA-256 of the data, prefixed by "sha256:",
+	// so the masked data can still be correlated
+	MaskHash MaskMode = "hash"
+)
+
+// Sensitive marks the data of a SchemaNode as sensitive (see SchemaNode.SetSensitive):
+//   - the data, and all the data nested in it, are redacted in the SchemaError(s) messages and JSON
+//   - the data is masked with Mask on SchemaActionSerialize
+type Sensitive struct {
+	Mask MaskMode `json:"mask,omitempty"`
+}
+
+// SensitiveError wraps the errors of a sensitive SchemaNode, so their messages never contain the data,
+// the original error can still be retrieved with errors.Is, errors.As or Unwrap
+type SensitiveError struct {
+	err error
+}
+
+func (e SensitiveError) Error() string {
+	return "the sensitive data does not validate"
+}
+
+func (e SensitiveError) Unwrap() error {
+	return e.err
+}
+
+func (s *Sensitive) mode() MaskMode {
+	if s == nil || s.Mask == "" {
+		return MaskRedact
+	}
+	return s.Mask
+}
+
+func (s *Sensitive) validate() error {
+	switch s.mode() {
+	case MaskRedact, MaskLast4, MaskHash:
+		return nil
+	}
+	return fmt.Errorf("%w %s", ErrInvalidMask, s.Mask)
+}
+
+// mask the data according to the Sensitive MaskMode
+func (s *Sensitive) mask(data Data) (Data, error) {
+	switch s.mode() {
+	case MaskRedact:
+		return RedactedValue, nil
+	case MaskLast4:
+		str, err := sensitiveString(data)
+		if err != nil {
+			return nil, err
+		}
+		n := utf8.RuneCountInString(str)
+		if n <= 4 {
+			return strings.Repeat("*", n), nil
+		}
+		runes := []rune(str)
+		return strings.Repeat("*", n-4) + string(runes[n-4:]), nil
+	case MaskHash:
+		str, err := sensitiveString(data)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256([]byte(str))
+		return "sha256:" + hex.EncodeToString(sum[:]), nil
+	}
+
+	return nil, fmt.Errorf("%w %s", ErrInvalidMask, s.Mask)
+}
+
+// sensitiveString return the string to mask, the data is used as is if it is a string, otherwise it is JSON encoded
+func sensitiveString(data Data) (string, error) {
+	if str, ok := data.(string); ok {
+		return str, nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("cannot mask sensitive data: %w", err)
+	}
+	return string(b), nil
+}
+
+// sensitiveErrors wraps all the errors in a SensitiveError
+func sensitiveErrors(path Path, err error) *SchemaError {
+	schemaError := NewSchemaWithCasting(path, err)
+	for i, e := range schemaError.Errors {
+		if _, ok := e.err.(SensitiveError); !ok {
+			schemaError.Errors[i].err = SensitiveError{err: e.err}
+		}
+	}
+	return schemaError
+}
+
+// isSensitive return true if the data of schemaNode is sensitive,
+// the data of EncryptedType is always sensitive since it is encrypted at rest
+func isSensitive(schemaNode *SchemaNode) bool {
+	if schemaNode == nil {
+		return false
+	}
+	if schemaNode.Sensitive != nil {
+		return true
+	}
+	_, ok := schemaNode.Type().(*EncryptedType)
+	return ok
+}
+
+// hasSensitive return true if schemaNode or any of its descendants is sensitive
+func hasSensitive(schemaNode *SchemaNode) bool {
+	return hasSensitiveVisited(schemaNode, map[*SchemaNode]struct{}{})
+}
+
+func hasSensitiveVisited(schemaNode *SchemaNode, visited map[*SchemaNode]struct{}) bool {
+	if schemaNode == nil {
+		return false
+	}
+	if isSensitive(schemaNode) {
+		return true
+	}
+	if _, ok := visited[schemaNode]; ok {
+		return false
+	}
+	visited[schemaNode] = struct{}{}
+
+	if schemaNode.Type() == nil {
+		return false
+	}
+	children, _ := schemaNode.Children()
+	for _, child := range children {
+		if hasSensitiveVisited(child, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactData return a copy of data where all the data of the sensitive SchemaNode(s) are replaced with RedactedValue.
+// The data is redacted precisely for ObjectType, ListType and TupleType, for the other parent SchemaType(s)
+// with a sensitive descendant all the data is redacted, since it is not known which part of the data is sensitive
+func redactData(schemaNode *SchemaNode, data Data) Data {
+	if !hasSensitive(schemaNode) {
+		return data
+	}
+	if isSensitive(schemaNode) {
+		return RedactedValue
+	}
+
+	switch t := schemaNode.Type().(type) {
+	case *ObjectType:
+		if d, ok := data.(map[string]interface{}); ok {
+			redacted := map[string]interface{}{}
+			for k, v := range d {
+				redacted[k] = redactData(t.SchemaMap[k], v)
+			}
+			return redacted
+		}
+	case *ListType:
+		if d, ok := data.([]interface{}); ok {
+			redacted := make([]interface{}, len(d))
+			for i, v := range d {
+				redacted[i] = redactData(t.Type, v)
+			}
+			return redacted
+		}
+	case *TupleType:
+		if d, ok := data.([]interface{}); ok {
+			redacted := make([]interface{}, len(d))
+			for i, v := range d {
+				if i < len(t.Items) {
+					redacted[i] = redactData(t.Items[i], v)
+				} else {
+					redacted[i] = redactData(t.Additional, v)
+				}
+			}
+			return redacted
+		}
+	}
+
+	return RedactedValue
+}
+
+// redactPathValue return the Path value, redacted if any SchemaNode in the Path is sensitive (see redactData)
+func redactPathValue(path Path) Data {
+	for _, e := range path.elements {
+		if isSensitive(e.schemaNode) {
+			return RedactedValue
+		}
+	}
+	last := path.Last()
+	if last == nil {
+		return nil
+	}
+	return redactData(last.schemaNode, path.Value())
+}
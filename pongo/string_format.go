@@ -0,0 +1,192 @@
+package pongo
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrStringFormatNotRegistered = errors.New("string format not registered")
+var ErrStringFormatAlreadyRegistered = errors.New("string format already registered")
+var ErrFormatMismatch = errors.New("string does not match the format")
+
+// StringFormat is a format of StringType registered with RegisterStringFormat
+type StringFormat struct {
+	// Validate return an error if the string does not match the format
+	Validate func(s string) error
+	// Parse, if set, converts a valid string to a Go value when the StringType conversion is enabled
+	Parse func(s string) (Data, error)
+	// Format converts back to a string the Go values returned by Parse, ok is false if data is not handled
+	Format func(data Data) (s string, ok bool)
+}
+
+const (
+	StringFormatEmail        = "email"
+	StringFormatURI          = "uri"
+	StringFormatURIReference = "uri-reference"
+	StringFormatHostname     = "hostname"
+	StringFormatIPv4         = "ipv4"
+	StringFormatIPv6         = "ipv6"
+	StringFormatUUID         = "uuid"
+	StringFormatDate         = "date"
+	StringFormatTime         = "time"
+	StringFormatDuration     = "duration"
+)
+
+var hostnameRegexp = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+var uuidRegexp = regexp.MustCompile(`^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}$`)
+
+// isoDurationRegexp matches the ISO 8601 durations such as "P1Y2M10DT2H30M" or "PT1.5S",
+// the groups are the years, months, weeks, days, hours, minutes and seconds
+var isoDurationRegexp = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// isISODuration return true if s is an ISO 8601 duration with at least a component
+func isISODuration(s string) bool {
+	return isoDurationRegexp.MatchString(s) && s != "P" && !strings.HasSuffix(s, "T")
+}
+
+func formatMismatch(ok bool) error {
+	if !ok {
+		return ErrFormatMismatch
+	}
+	return nil
+}
+
+func formatAddr(data Data) (string, bool) {
+	addr, ok := data.(netip.Addr)
+	if !ok {
+		return "", false
+	}
+	return addr.String(), true
+}
+
+func formatURL(data Data) (string, bool) {
+	u, ok := data.(*url.URL)
+	if !ok || u == nil {
+		return "", false
+	}
+	return u.String(), true
+}
+
+var stringFormats = map[string]StringFormat{
+	StringFormatEmail: {
+		Validate: func(s string) error {
+			addr, err := mail.ParseAddress(s)
+			return formatMismatch(err == nil && addr.Address == s)
+		},
+	},
+	StringFormatURI: {
+		Validate: func(s string) error {
+			u, err := url.Parse(s)
+			return formatMismatch(err == nil && u.IsAbs())
+		},
+		Parse:  func(s string) (Data, error) { return url.Parse(s) },
+		Format: formatURL,
+	},
+	StringFormatURIReference: {
+		Validate: func(s string) error {
+			_, err := url.Parse(s)
+			return formatMismatch(err == nil)
+		},
+		Parse:  func(s string) (Data, error) { return url.Parse(s) },
+		Format: formatURL,
+	},
+	StringFormatHostname: {
+		Validate: func(s string) error {
+			return formatMismatch(len(s) <= 253 && hostnameRegexp.MatchString(s))
+		},
+	},
+	StringFormatIPv4: {
+		Validate: func(s string) error {
+			addr, err := netip.ParseAddr(s)
+			return formatMismatch(err == nil && addr.Is4())
+		},
+		Parse:  func(s string) (Data, error) { return netip.ParseAddr(s) },
+		Format: formatAddr,
+	},
+	StringFormatIPv6: {
+		Validate: func(s string) error {
+			addr, err := netip.ParseAddr(s)
+			return formatMismatch(err == nil && addr.Is6() && addr.Zone() == "")
+		},
+		Parse:  func(s string) (Data, error) { return netip.ParseAddr(s) },
+		Format: formatAddr,
+	},
+	StringFormatUUID: {
+		Validate: func(s string) error {
+			return formatMismatch(uuidRegexp.MatchString(s))
+		},
+	},
+	StringFormatDate: {
+		Validate: func(s string) error {
+			_, err := time.Parse("2006-01-02", s)
+			return formatMismatch(err == nil)
+		},
+	},
+	StringFormatTime: {
+		Validate: func(s string) error {
+			_, err := time.Parse("15:04:05.999999999Z07:00", s)
+			return formatMismatch(err == nil)
+		},
+	},
+	StringFormatDuration: {
+		Validate: func(s string) error {
+			return formatMismatch(isISODuration(s))
+		},
+	},
+}
+var stringFormatsMutex sync.RWMutex
+
+// RegisterStringFormat register globally a StringFormat with the given name, which should be namespaced
+// (e.g. "acme.io/sku"). An error is returned if the name is invalid or already registered
+func RegisterStringFormat(name string, format StringFormat) error {
+	if err := ValidateSchemaTypeID(name); err != nil {
+		return err
+	}
+	if format.Validate == nil {
+		return fmt.Errorf("cannot register string format %s, Validate is nil", name)
+	}
+
+	stringFormatsMutex.Lock()
+	defer stringFormatsMutex.Unlock()
+
+	if _, ok := stringFormats[name]; ok {
+		return fmt.Errorf("%w: %s", ErrStringFormatAlreadyRegistered, name)
+	}
+	stringFormats[name] = format
+
+	return nil
+}
+
+// GetStringFormat return the StringFormat registered with name
+func GetStringFormat(name string) (StringFormat, error) {
+	stringFormatsMutex.RLock()
+	defer stringFormatsMutex.RUnlock()
+
+	format, ok := stringFormats[name]
+	if !ok {
+		return StringFormat{}, fmt.Errorf("%w: %s", ErrStringFormatNotRegistered, name)
+	}
+	return format, nil
+}
+
+// StringFormats return the names of the registered StringFormat(s) in alphabetical order
+func StringFormats() []string {
+	stringFormatsMutex.RLock()
+	defer stringFormatsMutex.RUnlock()
+
+	var names []string
+	for name := range stringFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
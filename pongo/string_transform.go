@@ -0,0 +1,108 @@
+package pongo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+var ErrInvalidStringTransform = errors.New("invalid string transform")
+
+// StringTransform is a normalization applied by StringType to the string before the length checks
+type StringTransform string
+
+const (
+	// StringTransformTrim removes the leading and trailing white spaces
+	StringTransformTrim StringTransform = "trim"
+	// StringTransformCollapse replaces every run of white spaces with a single space
+	StringTransformCollapse StringTransform = "collapse"
+	// StringTransformLower maps the string to lower case
+	StringTransformLower StringTransform = "lower"
+	// StringTransformUpper maps the string to upper case
+	StringTransformUpper StringTransform = "upper"
+	// StringTransformFold applies the Unicode case folding, to compare strings case-insensitively
+	StringTransformFold StringTransform = "fold"
+	// StringTransformNFC, StringTransformNFD, StringTransformNFKC and StringTransformNFKD
+	// apply the Unicode normalization form with the same name
+	StringTransformNFC  StringTransform = "nfc"
+	StringTransformNFD  StringTransform = "nfd"
+	StringTransformNFKC StringTransform = "nfkc"
+	StringTransformNFKD StringTransform = "nfkd"
+	// StringTransformStripControl removes the control characters, except tabs and new lines
+	StringTransformStripControl StringTransform = "stripControl"
+)
+
+var stringTransforms = map[StringTransform]func(string) string{
+	StringTransformTrim:         strings.TrimSpace,
+	StringTransformCollapse:     collapseSpaces,
+	StringTransformLower:        strings.ToLower,
+	StringTransformUpper:        strings.ToUpper,
+	StringTransformFold:         func(s string) string { return cases.Fold().String(s) },
+	StringTransformNFC:          norm.NFC.String,
+	StringTransformNFD:          norm.NFD.String,
+	StringTransformNFKC:         norm.NFKC.String,
+	StringTransformNFKD:         norm.NFKD.String,
+	StringTransformStripControl: stripControl,
+}
+
+func (t StringTransform) validate() error {
+	if _, ok := stringTransforms[t]; !ok {
+		return fmt.Errorf("%w %q", ErrInvalidStringTransform, t)
+	}
+	return nil
+}
+
+// Apply the StringTransform to s
+func (t StringTransform) Apply(s string) (string, error) {
+	fn, ok := stringTransforms[t]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrInvalidStringTransform, t)
+	}
+	return fn(s), nil
+}
+
+func (t *StringTransform) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("error decoding StringTransform, got error: %w", err)
+	}
+	if err := StringTransform(s).validate(); err != nil {
+		return err
+	}
+
+	*t = StringTransform(s)
+	return nil
+}
+
+func collapseSpaces(s string) string {
+	var b strings.Builder
+	var space bool
+
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			if !space {
+				b.WriteRune(' ')
+			}
+			space = true
+			continue
+		}
+		space = false
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func stripControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -70,10 +70,14 @@ func (e *AllOfType) SchemaTypeID() string {
 }
 
 func (e AllOfType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
+	return e.marshalJSONSchemaDraft(action, JSONSchemaDraft07)
+}
+
+func (e AllOfType) marshalJSONSchemaDraft(action SchemaAction, draft JSONSchemaDraft) ([]byte, error) {
 	var childrenJSON []json.RawMessage
 
 	for _, child := range e.Children() {
-		j, err := MarshalJSONSchema(child, action)
+		j, err := marshalChildJSONSchema(child, action, draft)
 		if err != nil {
 			return nil, err
 		}
@@ -90,3 +94,11 @@ func (e AllOfType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
 		"allOf": childrenJSON,
 	})
 }
+
+func (e AllOfType) Lint(path string) (findings []LintFinding) {
+	if len(e.SchemaList) == 0 {
+		return []LintFinding{NewLintFinding(path, LintSeverityWarning, "empty-combinator", "no elements set, any data will be accepted as nil")}
+	}
+
+	return nil
+}
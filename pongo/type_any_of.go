@@ -38,10 +38,14 @@ func (e *AnyOfType) SchemaTypeID() string {
 }
 
 func (e AnyOfType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
+	return e.marshalJSONSchemaDraft(action, JSONSchemaDraft07)
+}
+
+func (e AnyOfType) marshalJSONSchemaDraft(action SchemaAction, draft JSONSchemaDraft) ([]byte, error) {
 	var childrenJSON []json.RawMessage
 
 	for _, child := range e.Children() {
-		j, err := MarshalJSONSchema(child, action)
+		j, err := marshalChildJSONSchema(child, action, draft)
 		if err != nil {
 			return nil, err
 		}
@@ -55,3 +59,14 @@ func (e AnyOfType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
 		"anyOf": childrenJSON,
 	})
 }
+
+func (e AnyOfType) Lint(path string) (findings []LintFinding) {
+	if len(e.SchemaList) == 0 {
+		return []LintFinding{NewLintFinding(path, LintSeverityWarning, "empty-combinator", "no elements set, any data will be accepted as nil")}
+	}
+	for _, d := range lintDuplicateChildren(e.SchemaList) {
+		findings = append(findings, NewLintFinding(path, LintSeverityWarning, "duplicate-branch", "elements [%d] and [%d] are identical, element [%d] will never be used", d[0], d[1], d[1]))
+	}
+
+	return findings
+}
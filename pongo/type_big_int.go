@@ -0,0 +1,192 @@
+package pongo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// BigIntType SchemaType validates an arbitrary-precision integer, such as a 64-bit ID.
+// The data can be a *big.Int or a json.Number (see json.Decoder.UseNumber) with an integer value (e.g. "1e3" is 1000);
+// with the cast enabled, it can also be a plain integer string (e.g. "-12", not "1e3"), an integer or a float without a fractional part.
+// The data produced is a *big.Int, a decimal string or a json.Number, as set in Output for the action
+type BigIntType struct {
+	Cast   *ActionFlagProperty           `json:"cast,omitempty"`
+	Min    *BigIntProperty               `json:"min,omitempty"`
+	Max    *BigIntProperty               `json:"max,omitempty"`
+	Output *ActionProperty[NumberOutput] `json:"output,omitempty"`
+}
+
+func BigInt() *BigIntType {
+	return &BigIntType{}
+}
+
+func (b BigIntType) cast(action SchemaAction, dataPointer *DataPointer) (*big.Int, error) {
+	switch r := dataPointer.Get().(type) {
+	case *big.Int:
+		if r == nil {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s is a nil *big.Int", dataPointer.Path()))
+		}
+		return new(big.Int).Set(r), nil
+	case json.Number:
+		return b.parse(dataPointer, r.String())
+	}
+	if !b.Cast.GetAction(action) {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s is not a *big.Int or a json.Number", dataPointer.Path()))
+	}
+
+	switch r := dataPointer.Get().(type) {
+	case string:
+		if !bigIntInputRegexp.MatchString(r) {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %q is not an integer string", dataPointer.Path(), r))
+		}
+		return b.parse(dataPointer, r)
+	case int:
+		return big.NewInt(int64(r)), nil
+	case int32:
+		return big.NewInt(int64(r)), nil
+	case int64:
+		return big.NewInt(r), nil
+	case uint64:
+		return new(big.Int).SetUint64(r), nil
+	case float32:
+		return b.parseFloat(dataPointer, float64(r))
+	case float64:
+		return b.parseFloat(dataPointer, r)
+	}
+
+	return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s cannot cast to \"BigInt\"", dataPointer.Path()))
+}
+
+func (b BigIntType) parse(dataPointer *DataPointer, s string) (*big.Int, error) {
+	i, err := parseBigInt(s)
+	if err != nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %w", dataPointer.Path(), err))
+	}
+	return i, nil
+}
+
+func (b BigIntType) parseFloat(dataPointer *DataPointer, f float64) (*big.Int, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || math.Trunc(f) != f {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %v is not an integer", dataPointer.Path(), f))
+	}
+	i, _ := big.NewFloat(f).Int(nil)
+	return i, nil
+}
+
+func (b BigIntType) Process(action SchemaAction, dataPointer *DataPointer) (data Data, err error) {
+	if action != SchemaActionParse && action != SchemaActionSerialize {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), NewErrInvalidAction(b, action))
+	}
+	output := getNumberOutput(b.Output, action)
+	if err = output.validate(); err != nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as BigIntType at %s: %w", action, dataPointer.Path(), err))
+	}
+
+	v, err := b.cast(action, dataPointer)
+	if err != nil {
+		return nil, err
+	}
+
+	if m, ok := b.Min.Get(); ok && m.Cmp(v) > 0 {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value is %s (Min: %s)", dataPointer.Path(), v, m))
+	}
+	if m, ok := b.Max.Get(); ok && m.Cmp(v) < 0 {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value is %s (Max: %s)", dataPointer.Path(), v, m))
+	}
+
+	switch output {
+	case NumberOutputString:
+		return v.String(), nil
+	case NumberOutputNumber:
+		return json.Number(v.String()), nil
+	}
+	return v, nil
+}
+
+func (b BigIntType) SetCast(cast bool) *BigIntType {
+	b.Cast = b.Cast.Set(cast)
+	return &b
+}
+
+func (b BigIntType) SetCastActions(actions ...SchemaAction) *BigIntType {
+	b.Cast = b.Cast.SetActions(actions...)
+	return &b
+}
+
+func (b BigIntType) UnsetCastActions(actions ...SchemaAction) *BigIntType {
+	b.Cast.UnsetActions(actions...)
+	return &b
+}
+
+func (b BigIntType) SetMin(m *big.Int) *BigIntType {
+	b.Min = b.Min.Set(m)
+	return &b
+}
+
+func (b BigIntType) SetMax(m *big.Int) *BigIntType {
+	b.Max = b.Max.Set(m)
+	return &b
+}
+
+// SetOutput set the data produced on every action
+func (b BigIntType) SetOutput(output NumberOutput) *BigIntType {
+	b.Output = b.Output.SetDefault(output)
+	return &b
+}
+
+// SetOutputWithAction set the data produced on action
+func (b BigIntType) SetOutputWithAction(action SchemaAction, output NumberOutput) *BigIntType {
+	b.Output = b.Output.SetAction(action, output)
+	return &b
+}
+
+func (b *BigIntType) SchemaTypeID() string {
+	return "bigInt"
+}
+
+// MarshalJSONSchema return the schema of the data accepted on SchemaActionParse and of the data produced
+// on SchemaActionSerialize, where the NumberOutputBig output cannot be JSONschema-marshalled
+func (b BigIntType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
+	var integer = map[string]interface{}{"type": "integer"}
+	if m, ok := b.Min.Get(); ok {
+		integer["minimum"] = json.Number(m.String())
+	}
+	if m, ok := b.Max.Get(); ok {
+		integer["maximum"] = json.Number(m.String())
+	}
+
+	switch action {
+	case SchemaActionParse:
+		if !b.Cast.GetAction(action) {
+			return json.Marshal(integer)
+		}
+		return json.Marshal(map[string]interface{}{
+			"oneOf": []map[string]interface{}{
+				integer,
+				{"type": "string", "pattern": bigIntInputRegexp.String()},
+			},
+		})
+	case SchemaActionSerialize:
+		switch getNumberOutput(b.Output, action) {
+		case NumberOutputNumber:
+			return json.Marshal(integer)
+		case NumberOutputString:
+			return json.Marshal(map[string]interface{}{"type": "string", "pattern": bigIntPattern})
+		}
+		return nil, fmt.Errorf("%w: the output must be a string or a number in order to JSONschema-marshal the type", ErrSchemaNotJSONSchemaMarshalable)
+	}
+
+	return nil, NewErrInvalidAction(b, action)
+}
+
+func (b BigIntType) Lint(path string) (findings []LintFinding) {
+	if mi, ok := b.Min.Get(); ok {
+		if ma, ok := b.Max.Get(); ok && mi.Cmp(ma) > 0 {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "contradictory-range", "min %s is greater than max %s", mi, ma))
+		}
+	}
+
+	return append(findings, lintNumberOutput(path, b.Output)...)
+}
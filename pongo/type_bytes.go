@@ -9,7 +9,7 @@ import (
 type BytesType struct {
 	Cast   *ActionFlagProperty  `json:"cast,omitempty"`
 	MaxLen *NumberProperty[int] `json:"maxLen,omitempty"`
-	MinLen *NumberProperty[int] `json:"mixLen,omitempty"`
+	MinLen *NumberProperty[int] `json:"minLen,omitempty"`
 }
 
 func Bytes() *BytesType {
@@ -97,3 +97,7 @@ func (b BytesType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
 		"contentEncoding": "base64",
 	})
 }
+
+func (b BytesType) Lint(path string) []LintFinding {
+	return lintLength(path, b.MinLen, b.MaxLen)
+}
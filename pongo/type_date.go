@@ -0,0 +1,127 @@
+package pongo
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateType SchemaType validates a CivilDate, which is returned on SchemaActionParse
+// and serialized as "YYYY-MM-DD" on SchemaActionSerialize.
+// With the cast enabled, the data can also be a "YYYY-MM-DD" string or a time.Time, whose date is taken in its location
+type DateType struct {
+	Cast *ActionFlagProperty `json:"cast,omitempty"`
+	Min  *CivilDate          `json:"min,omitempty"`
+	Max  *CivilDate          `json:"max,omitempty"`
+}
+
+func Date() *DateType {
+	return &DateType{}
+}
+
+func (d DateType) cast(dataPointer *DataPointer) (CivilDate, error) {
+	switch r := dataPointer.Get().(type) {
+	case CivilDate:
+		return r, nil
+	case time.Time:
+		return CivilDateOf(r), nil
+	case string:
+		v, err := ParseCivilDate(r)
+		if err != nil {
+			return CivilDate{}, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s cannot cast from string: %w", dataPointer.Path(), err))
+		}
+		return v, nil
+	}
+
+	return CivilDate{}, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s cannot cast to \"Date\"", dataPointer.Path()))
+}
+
+func (d DateType) Process(action SchemaAction, dataPointer *DataPointer) (data Data, err error) {
+	var v CivilDate
+
+	if action != SchemaActionParse && action != SchemaActionSerialize {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), NewErrInvalidAction(d, action))
+	}
+
+	if d.Cast.GetAction(action) {
+		if v, err = d.cast(dataPointer); err != nil {
+			return nil, err
+		}
+	} else {
+		var ok bool
+		v, ok = dataPointer.Get().(CivilDate)
+		if !ok {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s is not a pongo.CivilDate", dataPointer.Path()))
+		}
+	}
+
+	if !v.IsValid() {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %s is not a valid date", dataPointer.Path(), v))
+	}
+	if d.Min != nil && v.Before(*d.Min) {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value is %s (Min: %s)", dataPointer.Path(), v, d.Min))
+	}
+	if d.Max != nil && v.After(*d.Max) {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value is %s (Max: %s)", dataPointer.Path(), v, d.Max))
+	}
+
+	if action == SchemaActionParse {
+		return v, nil
+	}
+	return v.String(), nil
+}
+
+func (d DateType) SetCast(cast bool) *DateType {
+	d.Cast = d.Cast.Set(cast)
+	return &d
+}
+
+func (d DateType) SetCastActions(actions ...SchemaAction) *DateType {
+	d.Cast = d.Cast.SetActions(actions...)
+	return &d
+}
+
+func (d DateType) UnsetCastActions(actions ...SchemaAction) *DateType {
+	d.Cast.UnsetActions(actions...)
+	return &d
+}
+
+func (d DateType) SetMin(m CivilDate) *DateType {
+	d.Min = &m
+	return &d
+}
+
+func (d DateType) SetMax(m CivilDate) *DateType {
+	d.Max = &m
+	return &d
+}
+
+func (d *DateType) SchemaTypeID() string {
+	return "date"
+}
+
+func (d DateType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
+	switch action {
+	case SchemaActionParse:
+		if !d.Cast.GetAction(action) {
+			return nil, fmt.Errorf("%w: Cast must be enabled in order to JSONschema-marshal the type", ErrSchemaNotJSONSchemaMarshalable)
+		}
+	case SchemaActionSerialize:
+	default:
+		return nil, NewErrInvalidAction(d, action)
+	}
+
+	return json.Marshal(map[string]interface{}{"type": "string", "format": "date"})
+}
+
+func (d DateType) Lint(path string) (findings []LintFinding) {
+	if d.Min != nil && d.Max != nil && d.Min.After(*d.Max) {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "contradictory-range", "min %s is after max %s", d.Min, d.Max))
+	}
+	for _, b := range []*CivilDate{d.Min, d.Max} {
+		if b != nil && !b.IsValid() {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-bound", "%s is not a valid date", b))
+		}
+	}
+	return findings
+}
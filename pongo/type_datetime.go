@@ -2,15 +2,85 @@ package pongo
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"time"
 )
 
+var ErrInvalidEpochUnit = errors.New("invalid epoch unit")
+
+// EpochUnit is the unit of the numbers casted by DatetimeType as unix epochs
+type EpochUnit string
+
+const (
+	// EpochUnitSeconds is the default EpochUnit
+	EpochUnitSeconds EpochUnit = "seconds"
+	EpochUnitMillis  EpochUnit = "millis"
+	EpochUnitMicros  EpochUnit = "micros"
+	EpochUnitNanos   EpochUnit = "nanos"
+)
+
+func (u EpochUnit) validate() error {
+	switch u {
+	case "", EpochUnitSeconds, EpochUnitMillis, EpochUnitMicros, EpochUnitNanos:
+		return nil
+	}
+	return fmt.Errorf("%w %q", ErrInvalidEpochUnit, u)
+}
+
+func (u *EpochUnit) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("error decoding EpochUnit, got error: %w", err)
+	}
+	if err := EpochUnit(s).validate(); err != nil {
+		return err
+	}
+
+	*u = EpochUnit(s)
+	return nil
+}
+
+// perSecond return how many units are in a second
+func (u EpochUnit) perSecond() int64 {
+	switch u {
+	case EpochUnitMillis:
+		return 1e3
+	case EpochUnitMicros:
+		return 1e6
+	case EpochUnitNanos:
+		return 1e9
+	}
+	return 1
+}
+
+// Time return the time.Time n units after the unix epoch
+func (u EpochUnit) Time(n int64) time.Time {
+	p := u.perSecond()
+	return time.Unix(n/p, n%p*(1e9/p))
+}
+
+// FloatTime return the time.Time n units after the unix epoch, keeping the fraction of n down to the nanosecond
+func (u EpochUnit) FloatTime(n float64) time.Time {
+	sec, frac := math.Modf(n / float64(u.perSecond()))
+	return time.Unix(int64(sec), int64(math.Round(frac*1e9)))
+}
+
+// DatetimeType SchemaType validates a time.Time, which is returned on SchemaActionParse
+// and formatted with the Format of the action on SchemaActionSerialize.
+// With the cast enabled, the data can also be a string, parsed with the Format of the action and then with each of Layouts,
+// or a number of EpochUnit since the unix epoch.
+// If Location is set, the time.Time is converted to it, and the strings without an UTC offset are parsed in it.
+// Before and After can be relative to the current time, given by ProcessOptions.Clock
 type DatetimeType struct {
-	Format *ActionProperty[string] `json:"format,omitempty"`
-	Cast   *ActionFlagProperty     `json:"cast,omitempty"`
-	Before *TimeProperty           `json:"before,omitempty"`
-	After  *TimeProperty           `json:"after,omitempty"`
+	Format    *ActionProperty[string] `json:"format,omitempty"`
+	Cast      *ActionFlagProperty     `json:"cast,omitempty"`
+	Before    *TimeProperty           `json:"before,omitempty"`
+	After     *TimeProperty           `json:"after,omitempty"`
+	Layouts   []string                `json:"layouts,omitempty"`
+	EpochUnit EpochUnit               `json:"epochUnit,omitempty"`
+	Location  *LocationProperty       `json:"location,omitempty"`
 }
 
 func Datetime() *DatetimeType {
@@ -21,23 +91,28 @@ func (d DatetimeType) Process(action SchemaAction, dataPointer *DataPointer) (da
 	var t time.Time
 	var ok bool
 
+	if err = d.EpochUnit.validate(); err != nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as DatetimeType at %s: %w", action, dataPointer.Path(), err))
+	}
+	location, hasLocation := d.Location.Get()
+
 	if d.Cast.GetAction(action) {
 		switch r := dataPointer.Get().(type) {
 		case string:
-			t, err = time.Parse(d.GetFormat(action), r)
+			t, err = d.parse(action, r, location)
 			if err != nil {
 				return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s cannot cast from string: %w", dataPointer.Path(), err))
 			}
 		case int:
-			t = time.Unix(int64(r), 0)
+			t = d.EpochUnit.Time(int64(r))
 		case int32:
-			t = time.Unix(int64(r), 0)
+			t = d.EpochUnit.Time(int64(r))
 		case int64:
-			t = time.Unix(r, 0)
+			t = d.EpochUnit.Time(r)
 		case float64:
-			t = time.Unix(int64(r), 0)
+			t = d.EpochUnit.FloatTime(r)
 		case float32:
-			t = time.Unix(int64(r), 0)
+			t = d.EpochUnit.FloatTime(float64(r))
 		case time.Time:
 			t = r
 		default:
@@ -51,16 +126,29 @@ func (d DatetimeType) Process(action SchemaAction, dataPointer *DataPointer) (da
 		}
 	}
 
-	if before, ok := d.Before.Get(); ok && before.Before(t) {
-		return time.Time{}, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value is %s (Max: %s)", dataPointer.Path(), t, before))
+	if hasLocation {
+		t = t.In(location)
+	}
+
+	now := dataPointer.Options().now()
+	before, ok, err := d.Before.GetAt(now)
+	if err != nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as DatetimeType at %s, invalid before: %w", action, dataPointer.Path(), err))
 	}
-	if after, ok := d.After.Get(); ok && after.After(t) {
-		return time.Time{}, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s length is %s (min: %s)", dataPointer.Path(), t, after))
+	if ok && before.Before(t) {
+		return time.Time{}, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value is %s (Max: %s)", dataPointer.Path(), t.Format(time.RFC3339Nano), d.Before.describe(before)))
+	}
+	after, ok, err := d.After.GetAt(now)
+	if err != nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as DatetimeType at %s, invalid after: %w", action, dataPointer.Path(), err))
+	}
+	if ok && after.After(t) {
+		return time.Time{}, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value is %s (Min: %s)", dataPointer.Path(), t.Format(time.RFC3339Nano), d.After.describe(after)))
 	}
 
 	switch action {
 	case SchemaActionSerialize:
-		return t.Format(time.RFC3339Nano), nil
+		return t.Format(d.GetFormat(action)), nil
 	case SchemaActionParse:
 		return t, nil
 	}
@@ -68,6 +156,30 @@ func (d DatetimeType) Process(action SchemaAction, dataPointer *DataPointer) (da
 	return nil, NewSchemaErrorWithError(dataPointer.Path(), NewErrInvalidAction(d, action))
 }
 
+// parse parse s with the Format of the action and then with each of Layouts, returning the error of the Format
+// if none of them matches. location is used for the strings without an UTC offset, UTC if it is nil
+func (d DatetimeType) parse(action SchemaAction, s string, location *time.Location) (t time.Time, err error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	if t, err = time.ParseInLocation(d.GetFormat(action), s, location); err == nil {
+		return t, nil
+	}
+	for _, layout := range d.Layouts {
+		if t, layoutErr := time.ParseInLocation(layout, s, location); layoutErr == nil {
+			return t, nil
+		}
+	}
+
+	return t, err
+}
+
+// layouts return the layouts accepted on action when casting a string
+func (d DatetimeType) layouts(action SchemaAction) []string {
+	return append([]string{d.GetFormat(action)}, d.Layouts...)
+}
+
 func (d *DatetimeType) SetFormat(f string) *DatetimeType {
 	d.Format = d.Format.SetDefault(f)
 	return d
@@ -112,21 +224,87 @@ func (d *DatetimeType) SetAfter(f time.Time) *DatetimeType {
 	return d
 }
 
+// SetBeforeRelative set Before to the current time shifted by period, an ISO 8601 duration
+// such as "PT0S" (not in the future) or "-P18Y" (at least 18 years ago)
+func (d *DatetimeType) SetBeforeRelative(period string) *DatetimeType {
+	d.Before = d.Before.SetRelative(period)
+	return d
+}
+
+// SetAfterRelative set After to the current time shifted by period, an ISO 8601 duration
+// such as "-P90D" (within the last 90 days)
+func (d *DatetimeType) SetAfterRelative(period string) *DatetimeType {
+	d.After = d.After.SetRelative(period)
+	return d
+}
+
+// SetLayouts set the layouts accepted when casting a string, after the Format of the action
+func (d DatetimeType) SetLayouts(layouts ...string) *DatetimeType {
+	d.Layouts = layouts
+	return &d
+}
+
+func (d DatetimeType) SetEpochUnit(unit EpochUnit) *DatetimeType {
+	d.EpochUnit = unit
+	return &d
+}
+
+func (d DatetimeType) SetLocation(location *time.Location) *DatetimeType {
+	d.Location = d.Location.Set(location)
+	return &d
+}
+
+func (d DatetimeType) UnsetLocation() *DatetimeType {
+	d.Location = nil
+	return &d
+}
+
 func (d *DatetimeType) SchemaTypeID() string {
 	return "datetime"
 }
 
+// MarshalJSONSchema return a string with the "date-time" format if all the layouts accepted
+// (SchemaActionParse) or produced (SchemaActionSerialize) on action are RFC 3339 layouts
 func (d DatetimeType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
-	if !d.Cast.GetAction(action) {
-		return nil, fmt.Errorf("%w: Cast must be enabled in order to JSONschema-marshal the type", ErrSchemaNotJSONSchemaMarshalable)
-	}
-
-	if action != SchemaActionParse {
+	var layouts []string
+	switch action {
+	case SchemaActionParse:
+		if !d.Cast.GetAction(action) {
+			return nil, fmt.Errorf("%w: Cast must be enabled in order to JSONschema-marshal the type", ErrSchemaNotJSONSchemaMarshalable)
+		}
+		layouts = d.layouts(action)
+	case SchemaActionSerialize:
+		layouts = []string{d.GetFormat(action)}
+	default:
 		return nil, NewErrInvalidAction(d, action)
 	}
 
+	for _, layout := range layouts {
+		if layout != time.RFC3339 && layout != time.RFC3339Nano {
+			return json.Marshal(map[string]interface{}{"type": "string"})
+		}
+	}
 	return json.Marshal(map[string]interface{}{
 		"type":   "string",
 		"format": "date-time",
 	})
 }
+
+func (d DatetimeType) Lint(path string) (findings []LintFinding) {
+	now := time.Now()
+	after, hasAfter, afterErr := d.After.GetAt(now)
+	before, hasBefore, beforeErr := d.Before.GetAt(now)
+	for _, err := range []error{afterErr, beforeErr} {
+		if err != nil {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-relative-bound", "%s", err))
+		}
+	}
+	if hasAfter && hasBefore && afterErr == nil && beforeErr == nil && after.After(before) {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "contradictory-range", "after %s is later than before %s", d.After.describe(after), d.Before.describe(before)))
+	}
+	if err := d.EpochUnit.validate(); err != nil {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-epoch-unit", "%s", err))
+	}
+
+	return findings
+}
@@ -0,0 +1,256 @@
+package pongo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// DecimalType SchemaType validates an arbitrary-precision decimal, such as a money amount.
+// The data can be a *big.Rat or a json.Number (see json.Decoder.UseNumber); with the cast enabled,
+// it can also be a decimal string, an integer or a float, which is read from its shortest representation (e.g. 0.1 is "0.1").
+// A value with more decimal digits than Scale is rounded with Rounding, or rejected if Rounding is RoundingNone;
+// Precision is the maximum number of digits, as in the SQL DECIMAL(precision, scale).
+// The data produced is a *big.Rat, a decimal string or a json.Number, as set in Output for the action
+type DecimalType struct {
+	Cast      *ActionFlagProperty           `json:"cast,omitempty"`
+	Precision *NumberProperty[int]          `json:"precision,omitempty"`
+	Scale     *NumberProperty[int]          `json:"scale,omitempty"`
+	Rounding  RoundingMode                  `json:"rounding,omitempty"`
+	Min       *DecimalProperty              `json:"min,omitempty"`
+	Max       *DecimalProperty              `json:"max,omitempty"`
+	Output    *ActionProperty[NumberOutput] `json:"output,omitempty"`
+}
+
+func Decimal() *DecimalType {
+	return &DecimalType{}
+}
+
+func (d DecimalType) cast(action SchemaAction, dataPointer *DataPointer) (*big.Rat, error) {
+	switch r := dataPointer.Get().(type) {
+	case *big.Rat:
+		if r == nil {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s is a nil *big.Rat", dataPointer.Path()))
+		}
+		return new(big.Rat).Set(r), nil
+	case json.Number:
+		return d.parse(dataPointer, r.String())
+	}
+	if !d.Cast.GetAction(action) {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s is not a *big.Rat or a json.Number", dataPointer.Path()))
+	}
+
+	switch r := dataPointer.Get().(type) {
+	case string:
+		return d.parse(dataPointer, r)
+	case int:
+		return new(big.Rat).SetInt64(int64(r)), nil
+	case int32:
+		return new(big.Rat).SetInt64(int64(r)), nil
+	case int64:
+		return new(big.Rat).SetInt64(r), nil
+	case uint64:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(r)), nil
+	case *big.Int:
+		if r == nil {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s is a nil *big.Int", dataPointer.Path()))
+		}
+		return new(big.Rat).SetInt(r), nil
+	case float32:
+		return d.parseFloat(dataPointer, float64(r), 32)
+	case float64:
+		return d.parseFloat(dataPointer, r, 64)
+	}
+
+	return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s cannot cast to \"Decimal\"", dataPointer.Path()))
+}
+
+func (d DecimalType) parse(dataPointer *DataPointer, s string) (*big.Rat, error) {
+	r, err := parseDecimal(s)
+	if err != nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %w", dataPointer.Path(), err))
+	}
+	return r, nil
+}
+
+func (d DecimalType) parseFloat(dataPointer *DataPointer, f float64, bitSize int) (*big.Rat, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %v is not a decimal", dataPointer.Path(), f))
+	}
+	return d.parse(dataPointer, strconv.FormatFloat(f, 'g', -1, bitSize))
+}
+
+func (d DecimalType) Process(action SchemaAction, dataPointer *DataPointer) (data Data, err error) {
+	if action != SchemaActionParse && action != SchemaActionSerialize {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), NewErrInvalidAction(d, action))
+	}
+	output := getNumberOutput(d.Output, action)
+	for _, err = range []error{d.Rounding.validate(), output.validate()} {
+		if err != nil {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as DecimalType at %s: %w", action, dataPointer.Path(), err))
+		}
+	}
+
+	v, err := d.cast(action, dataPointer)
+	if err != nil {
+		return nil, err
+	}
+
+	scale, hasScale := d.Scale.Get()
+	if hasScale && scale < 0 {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as DecimalType at %s: scale %d is negative", action, dataPointer.Path(), scale))
+	}
+	if hasScale {
+		rounded, ok := d.Rounding.Round(v, scale)
+		if !ok {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value %s has more than %d decimal digits", dataPointer.Path(), describeDecimal(v), scale))
+		}
+		v = rounded
+	} else {
+		var finite bool
+		if scale, finite = decimalScale(v); !finite {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value %s has no finite decimal representation", dataPointer.Path(), describeDecimal(v)))
+		}
+	}
+
+	if p, ok := d.Precision.Get(); ok {
+		if digits := integerDigits(v) + scale; digits > p {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value %s has %d digits (Precision: %d)", dataPointer.Path(), formatDecimal(v, scale), digits, p))
+		}
+	}
+	if m, ok := d.Min.Get(); ok && m.Cmp(v) > 0 {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value is %s (Min: %s)", dataPointer.Path(), formatDecimal(v, scale), formatDecimal(m, -1)))
+	}
+	if m, ok := d.Max.Get(); ok && m.Cmp(v) < 0 {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value is %s (Max: %s)", dataPointer.Path(), formatDecimal(v, scale), formatDecimal(m, -1)))
+	}
+
+	switch output {
+	case NumberOutputString:
+		return formatDecimal(v, scale), nil
+	case NumberOutputNumber:
+		return json.Number(formatDecimal(v, scale)), nil
+	}
+	return v, nil
+}
+
+func (d DecimalType) SetCast(cast bool) *DecimalType {
+	d.Cast = d.Cast.Set(cast)
+	return &d
+}
+
+func (d DecimalType) SetCastActions(actions ...SchemaAction) *DecimalType {
+	d.Cast = d.Cast.SetActions(actions...)
+	return &d
+}
+
+func (d DecimalType) UnsetCastActions(actions ...SchemaAction) *DecimalType {
+	d.Cast.UnsetActions(actions...)
+	return &d
+}
+
+func (d DecimalType) SetPrecision(precision int) *DecimalType {
+	d.Precision = d.Precision.Set(precision)
+	return &d
+}
+
+func (d DecimalType) SetScale(scale int) *DecimalType {
+	d.Scale = d.Scale.Set(scale)
+	return &d
+}
+
+func (d DecimalType) SetRounding(mode RoundingMode) *DecimalType {
+	d.Rounding = mode
+	return &d
+}
+
+func (d DecimalType) SetMin(m *big.Rat) *DecimalType {
+	d.Min = d.Min.Set(m)
+	return &d
+}
+
+func (d DecimalType) SetMax(m *big.Rat) *DecimalType {
+	d.Max = d.Max.Set(m)
+	return &d
+}
+
+// SetOutput set the data produced on every action
+func (d DecimalType) SetOutput(output NumberOutput) *DecimalType {
+	d.Output = d.Output.SetDefault(output)
+	return &d
+}
+
+// SetOutputWithAction set the data produced on action
+func (d DecimalType) SetOutputWithAction(action SchemaAction, output NumberOutput) *DecimalType {
+	d.Output = d.Output.SetAction(action, output)
+	return &d
+}
+
+func (d *DecimalType) SchemaTypeID() string {
+	return "decimal"
+}
+
+// MarshalJSONSchema return the schema of the data accepted on SchemaActionParse and of the data produced
+// on SchemaActionSerialize, where the NumberOutputBig output cannot be JSONschema-marshalled
+func (d DecimalType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
+	var number = map[string]interface{}{"type": "number"}
+	if m, ok := d.Min.Get(); ok {
+		number["minimum"] = json.Number(formatDecimal(m, -1))
+	}
+	if m, ok := d.Max.Get(); ok {
+		number["maximum"] = json.Number(formatDecimal(m, -1))
+	}
+
+	switch action {
+	case SchemaActionParse:
+		if !d.Cast.GetAction(action) {
+			return json.Marshal(number)
+		}
+		return json.Marshal(map[string]interface{}{
+			"oneOf": []map[string]interface{}{
+				number,
+				{"type": "string", "pattern": decimalRegexp.String()},
+			},
+		})
+	case SchemaActionSerialize:
+		switch getNumberOutput(d.Output, action) {
+		case NumberOutputNumber:
+			return json.Marshal(number)
+		case NumberOutputString:
+			return json.Marshal(map[string]interface{}{"type": "string", "pattern": decimalOutputPattern})
+		}
+		return nil, fmt.Errorf("%w: the output must be a string or a number in order to JSONschema-marshal the type", ErrSchemaNotJSONSchemaMarshalable)
+	}
+
+	return nil, NewErrInvalidAction(d, action)
+}
+
+func (d DecimalType) Lint(path string) (findings []LintFinding) {
+	if mi, ok := d.Min.Get(); ok {
+		if ma, ok := d.Max.Get(); ok && mi.Cmp(ma) > 0 {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "contradictory-range", "min %s is greater than max %s", formatDecimal(mi, -1), formatDecimal(ma, -1)))
+		}
+	}
+
+	scale, hasScale := d.Scale.Get()
+	precision, hasPrecision := d.Precision.Get()
+	if hasScale && scale < 0 {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-precision", "scale %d is negative", scale))
+	}
+	if hasPrecision && precision < 1 {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-precision", "precision %d is lower than 1", precision))
+	}
+	if hasScale && hasPrecision && scale > precision {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-precision", "scale %d is greater than precision %d", scale, precision))
+	}
+
+	if err := d.Rounding.validate(); err != nil {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-rounding-mode", "%s", err))
+	} else if d.Rounding != RoundingNone && !hasScale {
+		findings = append(findings, NewLintFinding(path, LintSeverityWarning, "useless-rounding", "rounding %s has no effect without a scale", d.Rounding))
+	}
+
+	return append(findings, lintNumberOutput(path, d.Output)...)
+}
@@ -0,0 +1,118 @@
+package pongo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// DiscriminatorType SchemaType process an object with the SchemaNode of Mapping selected by the value of
+// its Property, so tagged unions such as {"kind": "card", ...} and {"kind": "iban", ...} are processed only
+// by the matching branch, instead of trying all of them as OneOfType does.
+// The branches receive the whole object, Property included
+type DiscriminatorType struct {
+	Property string    `json:"property"`
+	Mapping  SchemaMap `json:"mapping"`
+}
+
+func Discriminator(property string, mapping O) *DiscriminatorType {
+	return &DiscriminatorType{
+		Property: property,
+		Mapping:  mapping.SchemaMap(),
+	}
+}
+
+func (d DiscriminatorType) Process(action SchemaAction, dataPointer *DataPointer) (Data, error) {
+	object, ok := dataPointer.Get().(map[string]interface{})
+	if !ok {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot validate data as DiscriminatorType at %s, not an \"Object\"", dataPointer.Path()))
+	}
+
+	value, ok := object[d.Property]
+	if !ok {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot validate data as DiscriminatorType at %s, missing discriminator property %s", dataPointer.Path(), d.Property))
+	}
+	key, ok := value.(string)
+	if !ok {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot validate data as DiscriminatorType at %s, discriminator property %s is not a \"String\"", dataPointer.Path(), d.Property))
+	}
+
+	branch := d.Mapping[key]
+	if branch == nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot validate data as DiscriminatorType at %s, unknown %s %q, expected one of %v", dataPointer.Path(), d.Property, key, d.keys()))
+	}
+
+	return branch.Process(action, dataPointer)
+}
+
+// keys return the Mapping keys with a non-nil SchemaNode, sorted
+func (d DiscriminatorType) keys() []string {
+	var keys []string
+	for key, branch := range d.Mapping {
+		if branch != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (d *DiscriminatorType) SchemaTypeID() string {
+	return "discriminator"
+}
+
+func (d *DiscriminatorType) Children() SchemaList {
+	list := SchemaList{}
+	for _, key := range d.keys() {
+		list = append(list, d.Mapping[key])
+	}
+	return list
+}
+
+// MarshalJSONSchema export the DiscriminatorType as a oneOf, where every branch requires
+// the discriminator property to be equal to its mapping key
+func (d DiscriminatorType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
+	return d.marshalJSONSchemaDraft(action, JSONSchemaDraft07)
+}
+
+func (d DiscriminatorType) marshalJSONSchemaDraft(action SchemaAction, draft JSONSchemaDraft) ([]byte, error) {
+	var branchesJSON []interface{}
+
+	for _, key := range d.keys() {
+		discriminatorJSON := map[string]interface{}{
+			"properties": map[string]interface{}{
+				d.Property: map[string]interface{}{"const": key},
+			},
+			"required": []string{d.Property},
+		}
+
+		j, err := marshalChildJSONSchema(d.Mapping[key], action, draft)
+		if err != nil {
+			return nil, err
+		}
+		if j == nil {
+			branchesJSON = append(branchesJSON, discriminatorJSON)
+			continue
+		}
+
+		branchesJSON = append(branchesJSON, map[string]interface{}{
+			"allOf": []interface{}{discriminatorJSON, json.RawMessage(j)},
+		})
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"type":  "object",
+		"oneOf": branchesJSON,
+	})
+}
+
+func (d DiscriminatorType) Lint(path string) (findings []LintFinding) {
+	if d.Property == "" {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "empty-discriminator", "no discriminator property set"))
+	}
+	if len(d.keys()) == 0 {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "empty-combinator", "no mapping set, no data can be validated"))
+	}
+
+	return findings
+}
@@ -0,0 +1,210 @@
+package pongo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var ErrInvalidDurationFormat = errors.New("invalid duration format")
+
+// DurationFormat is the format of the data serialized by DurationType
+type DurationFormat string
+
+const (
+	// DurationFormatGo serializes the duration as time.Duration.String (e.g. "1h30m0s"), it is the default DurationFormat
+	DurationFormatGo DurationFormat = "go"
+	// DurationFormatISO serializes the duration as an ISO 8601 duration in hours, minutes and seconds (e.g. "PT1H30M")
+	DurationFormatISO DurationFormat = "iso8601"
+	// DurationFormatSeconds serializes the duration as a float64 number of seconds (e.g. 5400)
+	DurationFormatSeconds DurationFormat = "seconds"
+)
+
+func (f DurationFormat) validate() error {
+	switch f {
+	case "", DurationFormatGo, DurationFormatISO, DurationFormatSeconds:
+		return nil
+	}
+	return fmt.Errorf("%w %q", ErrInvalidDurationFormat, f)
+}
+
+func (f *DurationFormat) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("error decoding DurationFormat, got error: %w", err)
+	}
+	if err := DurationFormat(s).validate(); err != nil {
+		return err
+	}
+
+	*f = DurationFormat(s)
+	return nil
+}
+
+// DurationType SchemaType validates a time.Duration, which is returned on SchemaActionParse
+// and serialized in Format on SchemaActionSerialize.
+// With the cast enabled, the data can also be a Go duration string (e.g. "1h30m"), an ISO 8601 duration
+// without years and months (e.g. "PT15M" or "P1D", a day is 24 hours long) or a number of seconds
+type DurationType struct {
+	Cast   *ActionFlagProperty `json:"cast,omitempty"`
+	Min    *DurationProperty   `json:"min,omitempty"`
+	Max    *DurationProperty   `json:"max,omitempty"`
+	Format DurationFormat      `json:"format,omitempty"`
+}
+
+func Duration() *DurationType {
+	return &DurationType{}
+}
+
+func (d DurationType) cast(dataPointer *DataPointer) (time.Duration, error) {
+	switch r := dataPointer.Get().(type) {
+	case time.Duration:
+		return r, nil
+	case string:
+		v, err := parseDuration(r)
+		if err != nil {
+			return 0, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s cannot cast from string: %w", dataPointer.Path(), err))
+		}
+		return v, nil
+	case int:
+		return d.castSeconds(dataPointer, int64(r))
+	case int64:
+		return d.castSeconds(dataPointer, r)
+	case float32:
+		return d.castFloatSeconds(dataPointer, float64(r))
+	case float64:
+		return d.castFloatSeconds(dataPointer, r)
+	}
+
+	return 0, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s cannot cast to \"Duration\"", dataPointer.Path()))
+}
+
+func (d DurationType) castSeconds(dataPointer *DataPointer, seconds int64) (time.Duration, error) {
+	if seconds > math.MaxInt64/int64(time.Second) || seconds < math.MinInt64/int64(time.Second) {
+		return 0, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %d seconds overflows a time.Duration", dataPointer.Path(), seconds))
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func (d DurationType) castFloatSeconds(dataPointer *DataPointer, seconds float64) (time.Duration, error) {
+	if math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return 0, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %v is not a number of seconds", dataPointer.Path(), seconds))
+	}
+	// float64(math.MaxInt64) is 2^63, which is out of range as well
+	if nanos := seconds * float64(time.Second); nanos >= float64(math.MaxInt64) || nanos < float64(math.MinInt64) {
+		return 0, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %v seconds overflows a time.Duration", dataPointer.Path(), seconds))
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func (d DurationType) Process(action SchemaAction, dataPointer *DataPointer) (data Data, err error) {
+	var v time.Duration
+
+	if action != SchemaActionParse && action != SchemaActionSerialize {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), NewErrInvalidAction(d, action))
+	}
+
+	if d.Cast.GetAction(action) {
+		if v, err = d.cast(dataPointer); err != nil {
+			return nil, err
+		}
+	} else {
+		var ok bool
+		v, ok = dataPointer.Get().(time.Duration)
+		if !ok {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s is not a time.Duration", dataPointer.Path()))
+		}
+	}
+
+	if m, ok := d.Min.Get(); ok && m > v {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value is %s (Min: %s)", dataPointer.Path(), v, m))
+	}
+	if m, ok := d.Max.Get(); ok && m < v {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value is %s (Max: %s)", dataPointer.Path(), v, m))
+	}
+
+	if action == SchemaActionParse {
+		return v, nil
+	}
+
+	switch d.Format {
+	case "", DurationFormatGo:
+		return v.String(), nil
+	case DurationFormatISO:
+		return formatISODuration(v), nil
+	case DurationFormatSeconds:
+		return v.Seconds(), nil
+	}
+
+	return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as DurationType at %s: %w", action, dataPointer.Path(), d.Format.validate()))
+}
+
+func (d DurationType) SetCast(cast bool) *DurationType {
+	d.Cast = d.Cast.Set(cast)
+	return &d
+}
+
+func (d DurationType) SetCastActions(actions ...SchemaAction) *DurationType {
+	d.Cast = d.Cast.SetActions(actions...)
+	return &d
+}
+
+func (d DurationType) UnsetCastActions(actions ...SchemaAction) *DurationType {
+	d.Cast.UnsetActions(actions...)
+	return &d
+}
+
+func (d DurationType) SetMin(m time.Duration) *DurationType {
+	d.Min = d.Min.Set(m)
+	return &d
+}
+
+func (d DurationType) SetMax(m time.Duration) *DurationType {
+	d.Max = d.Max.Set(m)
+	return &d
+}
+
+func (d DurationType) SetFormat(format DurationFormat) *DurationType {
+	d.Format = format
+	return &d
+}
+
+func (d *DurationType) SchemaTypeID() string {
+	return "duration"
+}
+
+func (d DurationType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
+	switch action {
+	case SchemaActionParse:
+		if !d.Cast.GetAction(action) {
+			return nil, fmt.Errorf("%w: Cast must be enabled in order to JSONschema-marshal the type", ErrSchemaNotJSONSchemaMarshalable)
+		}
+		return json.Marshal(map[string]interface{}{
+			"type": []string{"string", "number"},
+		})
+	case SchemaActionSerialize:
+		switch d.Format {
+		case DurationFormatISO:
+			return json.Marshal(map[string]interface{}{"type": "string", "format": "duration"})
+		case DurationFormatSeconds:
+			return json.Marshal(map[string]interface{}{"type": "number"})
+		}
+		return json.Marshal(map[string]interface{}{"type": "string"})
+	}
+
+	return nil, NewErrInvalidAction(d, action)
+}
+
+func (d DurationType) Lint(path string) (findings []LintFinding) {
+	if m, ok := d.Min.Get(); ok {
+		if n, ok := d.Max.Get(); ok && m > n {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "contradictory-range", "min %s is greater than max %s", m, n))
+		}
+	}
+	if err := d.Format.validate(); err != nil {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-duration-format", "%s", err))
+	}
+	return findings
+}
@@ -0,0 +1,103 @@
+package pongo
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EncryptedType SchemaType handles a field encrypted at rest with the KeyProvider registered as Provider
+// (see RegisterKeyProvider): on SchemaActionParse the ciphertext string is decrypted and the JSON plaintext is
+// parsed by Type, on SchemaActionSerialize the data is serialized by Type and its JSON encoding is encrypted.
+// The plaintext is decoded as any JSON data (e.g. the numbers are float64, so IntType requires the cast).
+// The data of an EncryptedType is sensitive, so it is redacted in the errors as the data of a sensitive SchemaNode
+type EncryptedType struct {
+	Provider string      `json:"provider"`
+	Type     *SchemaNode `json:"type"`
+}
+
+func Encrypted(provider string, schema SchemaType) *EncryptedType {
+	if schema == nil {
+		return &EncryptedType{Provider: provider, Type: nil}
+	}
+	return &EncryptedType{
+		Provider: provider,
+		Type:     Schema(schema),
+	}
+}
+
+func (e EncryptedType) Process(action SchemaAction, dataPointer *DataPointer) (Data, error) {
+	if e.Type == nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as EncryptedType at %s, no schema for the plaintext set", action, dataPointer.Path()))
+	}
+	provider, err := GetKeyProvider(e.Provider)
+	if err != nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as EncryptedType at %s: %w", action, dataPointer.Path(), err))
+	}
+
+	switch action {
+	case SchemaActionParse:
+		ciphertext, ok := dataPointer.Get().(string)
+		if !ok {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s is not a \"string\"", dataPointer.Path()))
+		}
+		plaintext, err := provider.Decrypt(ciphertext)
+		if err != nil {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as EncryptedType at %s: %w", action, dataPointer.Path(), err))
+		}
+		var data Data
+		if err = json.Unmarshal(plaintext, &data); err != nil {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as EncryptedType at %s, the plaintext is not JSON", action, dataPointer.Path()))
+		}
+
+		parsed, err := e.Type.Parse(dataPointer.Push(e.Type, data, "plaintext"))
+		if err != nil {
+			return nil, sensitiveErrors(dataPointer.Path(), err)
+		}
+		return parsed, nil
+	case SchemaActionSerialize:
+		serialized, err := e.Type.Serialize(dataPointer.Push(e.Type, dataPointer.Get(), "plaintext"))
+		if err != nil {
+			return nil, sensitiveErrors(dataPointer.Path(), err)
+		}
+		plaintext, err := json.Marshal(serialized)
+		if err != nil {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as EncryptedType at %s, the plaintext is not JSON encodable", action, dataPointer.Path()))
+		}
+		ciphertext, err := provider.Encrypt(plaintext)
+		if err != nil {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as EncryptedType at %s: %w", action, dataPointer.Path(), err))
+		}
+		return ciphertext, nil
+	}
+
+	return nil, NewSchemaErrorWithError(dataPointer.Path(), NewErrInvalidAction(e, action))
+}
+
+func (e *EncryptedType) SchemaTypeID() string {
+	return "encrypted"
+}
+
+func (e *EncryptedType) Children() SchemaList {
+	if e.Type == nil {
+		return SchemaList{}
+	}
+	return SchemaList{e.Type}
+}
+
+// MarshalJSONSchema export the ciphertext as a string, since the plaintext is never on the wire
+func (e EncryptedType) MarshalJSONSchema(_ SchemaAction) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type": "string",
+	})
+}
+
+func (e EncryptedType) Lint(path string) (findings []LintFinding) {
+	if e.Type == nil {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "nil-encrypted-type", "no schema for the plaintext set, no data can be validated"))
+	}
+	// the KeyProvider can be registered after the schema is loaded, it is required only to process the data
+	if _, err := GetKeyProvider(e.Provider); err != nil {
+		findings = append(findings, NewLintFinding(path, LintSeverityWarning, "unregistered-key-provider", "%s", err))
+	}
+	return findings
+}
@@ -0,0 +1,127 @@
+package pongo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultExprCode is the ExprError code used when ExprType.Code is not set
+const DefaultExprCode = "expr"
+
+var ErrExprNotSatisfied = errors.New("expression not satisfied")
+
+// ExprError is the error returned by ExprType when its expression evaluates to false or cannot be evaluated,
+// it can be retrieved from the SchemaElementError(s) with errors.As
+type ExprError struct {
+	Code    string
+	Expr    string
+	Message string
+}
+
+func (e ExprError) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
+}
+
+func (e ExprError) Unwrap() error {
+	return ErrExprNotSatisfied
+}
+
+// ExprType checks the data with a boolean expression, which can reference the parent data to express
+// cross-field constraints such as "@.end > @.start" on an object (see expr.go for the expression syntax).
+// ExprType does not alter the data, so it is usually combined with other SchemaType(s) in AllOf.
+// When the expression is not satisfied an ExprError with Code and Message is returned
+type ExprType struct {
+	Expr    string `json:"expr"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	compiled     exprNode
+	compileErr   error
+	compiledExpr string
+}
+
+func Expr(expression string) *ExprType {
+	e := &ExprType{Expr: expression}
+	e.compile()
+	return e
+}
+
+func (e *ExprType) compile() {
+	e.compiled, e.compileErr = compileExpr(e.Expr)
+	e.compiledExpr = e.Expr
+}
+
+// program return the compiled expression, compiling Expr if the ExprType was not built with Expr
+// or if Expr has been changed after the compilation
+func (e ExprType) program() (exprNode, error) {
+	if e.compiledExpr != e.Expr || e.compiled == nil && e.compileErr == nil {
+		return compileExpr(e.Expr)
+	}
+	return e.compiled, e.compileErr
+}
+
+func (e ExprType) Process(action SchemaAction, dataPointer *DataPointer) (Data, error) {
+	if action != SchemaActionParse && action != SchemaActionSerialize {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), NewErrInvalidAction(e, action))
+	}
+	program, compileErr := e.program()
+	if compileErr != nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %w", dataPointer.Path(), compileErr))
+	}
+
+	exprErr := ExprError{Code: e.Code, Expr: e.Expr, Message: e.Message}
+	if exprErr.Code == "" {
+		exprErr.Code = DefaultExprCode
+	}
+
+	result, err := program.eval(exprEnv{dataPointer: dataPointer, now: dataPointer.Options().now})
+	if err != nil {
+		exprErr.Message = fmt.Sprintf("cannot evaluate expression %s: %s", e.Expr, err)
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %w", dataPointer.Path(), exprErr))
+	}
+	if b, ok := result.(bool); !ok {
+		exprErr.Message = fmt.Sprintf("expression %s evaluates to a %T, not to a bool", e.Expr, result)
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %w", dataPointer.Path(), exprErr))
+	} else if !b {
+		if exprErr.Message == "" {
+			exprErr.Message = fmt.Sprintf("expression %s is not satisfied", e.Expr)
+		}
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %w", dataPointer.Path(), exprErr))
+	}
+
+	return dataPointer.Get(), nil
+}
+
+func (e ExprType) SetCode(code string) *ExprType {
+	e.Code = code
+	return &e
+}
+
+func (e ExprType) SetMessage(message string) *ExprType {
+	e.Message = message
+	return &e
+}
+
+func (e *ExprType) SchemaTypeID() string {
+	return "expr"
+}
+
+func (e *ExprType) UnmarshalJSON(b []byte) error {
+	type exprType ExprType
+	var unmarshal exprType
+	if err := json.Unmarshal(b, &unmarshal); err != nil {
+		return err
+	}
+
+	*e = ExprType(unmarshal)
+	e.compile()
+	return e.compileErr
+}
+
+func (e ExprType) Lint(path string) []LintFinding {
+	if _, err := e.program(); err != nil {
+		return []LintFinding{NewLintFinding(path, LintSeverityError, "invalid-expression", "%s", err)}
+	}
+	return nil
+}
@@ -125,3 +125,13 @@ func (f64 Float64Type) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
 		},
 	})
 }
+
+func (f64 Float64Type) Lint(path string) []LintFinding {
+	if mi, ok := f64.Min.Get(); ok {
+		if ma, ok := f64.Max.Get(); ok && mi > ma {
+			return []LintFinding{NewLintFinding(path, LintSeverityError, "contradictory-range", "min %f is greater than max %f", mi, ma)}
+		}
+	}
+
+	return nil
+}
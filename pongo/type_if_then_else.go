@@ -0,0 +1,101 @@
+package pongo
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// IfThenElseType SchemaType process the data with Then if the data is validated by If, with Else otherwise.
+// The If SchemaNode only selects the branch, its output is discarded; if the selected branch is not set
+// the data is returned unchanged
+type IfThenElseType struct {
+	If   *SchemaNode `json:"if"`
+	Then *SchemaNode `json:"then,omitempty"`
+	Else *SchemaNode `json:"else,omitempty"`
+}
+
+// IfThenElse return a new IfThenElseType, thenSchema and elseSchema can be nil
+func IfThenElse(ifSchema SchemaType, thenSchema SchemaType, elseSchema SchemaType) *IfThenElseType {
+	i := &IfThenElseType{}
+	if ifSchema != nil {
+		i.If = Schema(ifSchema)
+	}
+	if thenSchema != nil {
+		i.Then = Schema(thenSchema)
+	}
+	if elseSchema != nil {
+		i.Else = Schema(elseSchema)
+	}
+	return i
+}
+
+func (i IfThenElseType) Process(action SchemaAction, dataPointer *DataPointer) (Data, error) {
+	if i.If == nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as IfThenElseType at %s, no if schema set", action, dataPointer.Path()))
+	}
+
+	branch := i.Else
+	if _, err := i.If.Process(action, dataPointer.Clone()); err == nil {
+		branch = i.Then
+	}
+
+	if branch == nil {
+		return dataPointer.Get(), nil
+	}
+	return branch.Process(action, dataPointer)
+}
+
+func (i *IfThenElseType) SchemaTypeID() string {
+	return "ifThenElse"
+}
+
+func (i *IfThenElseType) Children() SchemaList {
+	list := SchemaList{}
+	for _, child := range []*SchemaNode{i.If, i.Then, i.Else} {
+		if child != nil {
+			list = append(list, child)
+		}
+	}
+	return list
+}
+
+// MarshalJSONSchema export the if/then/else keywords; without an If no data is valid, so {"not":{}} is exported.
+// If the If exports no JSON Schema the branch taken cannot be expressed, so the schema cannot be exported
+func (i IfThenElseType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
+	return i.marshalJSONSchemaDraft(action, JSONSchemaDraft07)
+}
+
+func (i IfThenElseType) marshalJSONSchemaDraft(action SchemaAction, draft JSONSchemaDraft) ([]byte, error) {
+	if i.If == nil {
+		return json.Marshal(map[string]interface{}{"not": map[string]interface{}{}})
+	}
+
+	jsonObject := map[string]json.RawMessage{}
+
+	for key, child := range map[string]*SchemaNode{"if": i.If, "then": i.Then, "else": i.Else} {
+		if child == nil {
+			continue
+		}
+		j, err := marshalChildJSONSchema(child, action, draft)
+		if err != nil {
+			return nil, err
+		}
+		if j != nil {
+			jsonObject[key] = j
+		} else if key == "if" {
+			return nil, fmt.Errorf("%w: the if schema exports no JSON Schema", ErrSchemaNotJSONSchemaMarshalable)
+		}
+	}
+
+	return json.Marshal(jsonObject)
+}
+
+func (i IfThenElseType) Lint(path string) []LintFinding {
+	if i.If == nil {
+		return []LintFinding{NewLintFinding(path, LintSeverityError, "nil-if-type", "no if schema set, no data can be validated")}
+	}
+	if i.Then == nil && i.Else == nil {
+		return []LintFinding{NewLintFinding(path, LintSeverityWarning, "useless-condition", "neither then nor else schema is set, any data will be accepted")}
+	}
+	return nil
+}
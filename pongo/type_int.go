@@ -131,3 +131,13 @@ func (i IntType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
 		},
 	})
 }
+
+func (i IntType) Lint(path string) []LintFinding {
+	if mi, ok := i.Min.Get(); ok {
+		if ma, ok := i.Max.Get(); ok && mi > ma {
+			return []LintFinding{NewLintFinding(path, LintSeverityError, "contradictory-range", "min %d is greater than max %d", mi, ma)}
+		}
+	}
+
+	return nil
+}
@@ -3,12 +3,28 @@ package pongo
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
+// ListType SchemaType validates a list whose items are all validated by Type.
+//   - UniqueItems rejects the lists with duplicated items, comparing the processed items;
+//     UniqueBy compares only the value at the given dotted path of the items (e.g. "id" or "owner.id"),
+//     the items without a value at the path are never duplicates
+//   - Contains requires at least MinContains (1 if not set) and at most MaxContains items to be validated
+//     by the Contains SchemaNode, the items are processed anyway by Type
 type ListType struct {
 	Type   *SchemaNode          `json:"type"`
 	MinLen *NumberProperty[int] `json:"minLen,omitempty"`
 	MaxLen *NumberProperty[int] `json:"maxLen,omitempty"`
+
+	UniqueItems bool                 `json:"uniqueItems,omitempty"`
+	UniqueBy    string               `json:"uniqueBy,omitempty"`
+	Contains    *SchemaNode          `json:"contains,omitempty"`
+	MinContains *NumberProperty[int] `json:"minContains,omitempty"`
+	MaxContains *NumberProperty[int] `json:"maxContains,omitempty"`
+
+	// Workers overrides ProcessOptions.Workers for this ListType, it is a runtime setting and it is not marshalled
+	Workers int `json:"-"`
 }
 
 func List(schema SchemaType) *ListType {
@@ -40,24 +56,35 @@ func (l ListType) Process(action SchemaAction, dataPointer *DataPointer) (data D
 		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as ListType at %s, expected min lenght of the list at %d, got %d", action, dataPointer.Path(), l.MinLen, len(d)))
 	}
 
-	var processedSlice = []interface{}{}
+	if err = l.processContains(action, dataPointer, d); err != nil {
+		return nil, err
+	}
 
-	for key := range d {
+	workers := dataPointer.Options().Workers
+	if l.Workers > 0 {
+		workers = l.Workers
+	}
+
+	results, errs, err := processChildren(dataPointer.Options(), workers, len(d), func(key int) (Data, error) {
 		ptr := dataPointer.Push(l.Type, d[key], fmt.Sprintf("[%d]", key))
-		var item interface{}
 
 		switch action {
 		case SchemaActionSerialize:
-			item, err = l.Type.Serialize(ptr)
-			processedSlice = append(processedSlice, item)
+			return l.Type.Serialize(ptr)
 		case SchemaActionParse:
-			item, err = l.Type.Parse(ptr)
-			processedSlice = append(processedSlice, item)
+			return l.Type.Parse(ptr)
 		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as ListType at %s: %w", action, dataPointer.Path(), err))
+	}
 
-		if err != nil {
-			schemaError = schemaError.MergeWithCast(dataPointer.Path(), err)
-			continue
+	var processedSlice = []interface{}{}
+	for key := range d {
+		processedSlice = append(processedSlice, results[key])
+		if errs[key] != nil {
+			schemaError = schemaError.MergeWithCast(dataPointer.Path(), errs[key])
 		}
 	}
 
@@ -65,9 +92,95 @@ func (l ListType) Process(action SchemaAction, dataPointer *DataPointer) (data D
 		return nil, schemaError
 	}
 
+	if err = l.processUnique(dataPointer, d, processedSlice); err != nil {
+		return nil, err
+	}
+
 	return processedSlice, nil
 }
 
+// processContains checks the number of items validated by Contains
+func (l ListType) processContains(action SchemaAction, dataPointer *DataPointer, d []interface{}) error {
+	if l.Contains == nil {
+		return nil
+	}
+
+	var count int
+	for key := range d {
+		ptr := dataPointer.Push(l.Contains, d[key], fmt.Sprintf("[%d]", key))
+		if _, err := l.Contains.Process(action, ptr); err == nil {
+			count++
+		}
+	}
+
+	minContains, ok := l.MinContains.Get()
+	if !ok {
+		minContains = 1
+	}
+	if count < minContains {
+		return NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as ListType at %s, expected at least %d item(s) matching the contains schema, got %d", action, dataPointer.Path(), minContains, count))
+	}
+	if m, ok := l.MaxContains.Get(); ok && count > m {
+		return NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as ListType at %s, expected at most %d item(s) matching the contains schema, got %d", action, dataPointer.Path(), m, count))
+	}
+
+	return nil
+}
+
+// processUnique checks that the processed items (or their UniqueBy values) are unique,
+// the items are compared by their JSON encoding, an error is returned for every duplicated item
+func (l ListType) processUnique(dataPointer *DataPointer, d []interface{}, processedSlice []interface{}) error {
+	if !l.UniqueItems && l.UniqueBy == "" {
+		return nil
+	}
+
+	var schemaError = NewSchemaError()
+	var seen = map[string]int{}
+
+	for key, item := range processedSlice {
+		if l.UniqueBy != "" {
+			var ok bool
+			// as a NULL in a SQL UNIQUE column, an item without the value is never a duplicate
+			if item, ok = listUniqueByValue(item, l.UniqueBy); !ok {
+				continue
+			}
+		}
+
+		var id string
+		if encoded, err := json.Marshal(item); err == nil {
+			id = string(encoded)
+		} else {
+			id = fmt.Sprintf("%#v", item)
+		}
+
+		if first, ok := seen[id]; ok {
+			ptr := dataPointer.Push(l.Type, d[key], fmt.Sprintf("[%d]", key))
+			schemaError = schemaError.Append(ptr.Path(), fmt.Errorf("schema does not validate: %s is a duplicate of item [%d]", ptr.Path(), first))
+			continue
+		}
+		seen[id] = key
+	}
+
+	if len(schemaError.Errors) > 0 {
+		return schemaError
+	}
+	return nil
+}
+
+// listUniqueByValue return the value at the dotted path of item, ok is false if the path does not exist
+func listUniqueByValue(item interface{}, path string) (value interface{}, ok bool) {
+	for _, key := range strings.Split(path, PathSeparator) {
+		object, isObject := item.(map[string]interface{})
+		if !isObject {
+			return nil, false
+		}
+		if item, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return item, true
+}
+
 func (l ListType) SetMinLen(i int) *ListType {
 	l.MinLen = l.MinLen.Set(i)
 	return &l
@@ -78,15 +191,57 @@ func (l ListType) SetMaxLen(i int) *ListType {
 	return &l
 }
 
+func (l ListType) SetWorkers(workers int) *ListType {
+	l.Workers = workers
+	return &l
+}
+
+func (l ListType) SetUniqueItems(unique bool) *ListType {
+	l.UniqueItems = unique
+	return &l
+}
+
+// SetUniqueBy set the dotted path of the items value that must be unique (e.g. "id" for a list of objects)
+func (l ListType) SetUniqueBy(path string) *ListType {
+	l.UniqueBy = path
+	return &l
+}
+
+func (l ListType) SetContains(schema SchemaType) *ListType {
+	if schema == nil {
+		l.Contains = nil
+	} else {
+		l.Contains = Schema(schema)
+	}
+	return &l
+}
+
+func (l ListType) SetMinContains(i int) *ListType {
+	l.MinContains = l.MinContains.Set(i)
+	return &l
+}
+
+func (l ListType) SetMaxContains(i int) *ListType {
+	l.MaxContains = l.MaxContains.Set(i)
+	return &l
+}
+
 func (l *ListType) SchemaTypeID() string {
 	return "list"
 }
 
 func (l *ListType) Children() SchemaList {
+	if l.Contains != nil {
+		return SchemaList{l.Type, l.Contains}
+	}
 	return SchemaList{l.Type}
 }
 
 func (l ListType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
+	return l.marshalJSONSchemaDraft(action, JSONSchemaDraft07)
+}
+
+func (l ListType) marshalJSONSchemaDraft(action SchemaAction, draft JSONSchemaDraft) ([]byte, error) {
 	jsonObject := map[string]interface{}{
 		"type": "array",
 	}
@@ -97,6 +252,14 @@ func (l ListType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
 	if m, ok := l.MaxLen.Get(); ok {
 		jsonObject["maxItems"] = m
 	}
+	// UniqueBy cannot be expressed in JSON Schema
+	if l.UniqueItems && l.UniqueBy == "" {
+		jsonObject["uniqueItems"] = true
+	}
+
+	if err := l.marshalJSONSchemaContains(action, draft, jsonObject); err != nil {
+		return nil, err
+	}
 
 	if l.Type == nil {
 		return json.Marshal(jsonObject)
@@ -105,7 +268,7 @@ func (l ListType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
 	var childJSON json.RawMessage
 	var err error
 
-	childJSON, err = MarshalJSONSchema(l.Type, action)
+	childJSON, err = marshalChildJSONSchema(l.Type, action, draft)
 	if err != nil {
 		return nil, err
 	}
@@ -114,3 +277,59 @@ func (l ListType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
 
 	return json.Marshal(jsonObject)
 }
+
+// marshalJSONSchemaContains add contains, minContains and maxContains to jsonObject.
+// contains is omitted when MinContains is 0 and MaxContains is not set, since any list would be valid;
+// minContains and maxContains only exist since draft 2019-09, so they cannot be exported in draft-07
+func (l ListType) marshalJSONSchemaContains(action SchemaAction, draft JSONSchemaDraft, jsonObject map[string]interface{}) error {
+	if l.Contains == nil {
+		return nil
+	}
+	minContains, okMin := l.MinContains.Get()
+	maxContains, okMax := l.MaxContains.Get()
+	if okMin && minContains == 0 && !okMax {
+		return nil
+	}
+	if draft == JSONSchemaDraft07 && (okMin && minContains != 1 || okMax) {
+		return fmt.Errorf("%w: min and max contains require the JSON Schema draft 2020-12, see MarshalJSONSchemaWithDraft", ErrSchemaNotJSONSchemaMarshalable)
+	}
+
+	containsJSON, err := marshalChildJSONSchema(l.Contains, action, draft)
+	if err != nil {
+		return err
+	}
+	if containsJSON == nil {
+		containsJSON = json.RawMessage("{}")
+	}
+	jsonObject["contains"] = json.RawMessage(containsJSON)
+
+	// in draft-07 contains always requires at least one item, which is the only MinContains allowed above
+	if okMin && draft != JSONSchemaDraft07 {
+		jsonObject["minContains"] = minContains
+	}
+	if okMax {
+		jsonObject["maxContains"] = maxContains
+	}
+
+	return nil
+}
+
+func (l ListType) Lint(path string) []LintFinding {
+	findings := lintLength(path, l.MinLen, l.MaxLen)
+	mi, okMin := l.MinContains.Get()
+	ma, okMax := l.MaxContains.Get()
+	if l.Contains == nil && (okMin || okMax) {
+		findings = append(findings, NewLintFinding(path, LintSeverityWarning, "useless-contains", "min or max contains is set, but no contains schema is set"))
+	}
+	if okMin && okMax && mi > ma {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "contradictory-contains", "min contains %d is greater than max contains %d", mi, ma))
+	}
+	if m, ok := l.MaxLen.Get(); ok && okMin && mi > m {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "contradictory-contains", "min contains %d is greater than max length %d", mi, m))
+	}
+	if l.Type == nil {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "nil-list-type", "no SchemaType set for the list items"))
+	}
+
+	return findings
+}
@@ -0,0 +1,76 @@
+package pongo
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// NotType SchemaType validates the data only if it is NOT validated by Type,
+// the data is always returned unchanged, since the output of Type is never valid
+type NotType struct {
+	Type *SchemaNode `json:"type"`
+}
+
+func Not(schema SchemaType) *NotType {
+	if schema == nil {
+		return &NotType{Type: nil}
+	}
+	return &NotType{
+		Type: Schema(schema),
+	}
+}
+
+func (n NotType) Process(action SchemaAction, dataPointer *DataPointer) (Data, error) {
+	if n.Type == nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as NotType at %s, no schema to negate set", action, dataPointer.Path()))
+	}
+
+	if _, err := n.Type.Process(action, dataPointer.Clone()); err == nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s matches the negated schema", dataPointer.Path()))
+	}
+
+	return dataPointer.Get(), nil
+}
+
+func (n *NotType) SchemaTypeID() string {
+	return "not"
+}
+
+func (n *NotType) Children() SchemaList {
+	if n.Type == nil {
+		return SchemaList{}
+	}
+	return SchemaList{n.Type}
+}
+
+// MarshalJSONSchema export the negation of Type; without a Type no data is valid, so {"not":{}} is exported.
+// If Type exports no JSON Schema (it accepts any data, or it cannot be expressed as in ExprType),
+// its negation cannot be exported either
+func (n NotType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
+	return n.marshalJSONSchemaDraft(action, JSONSchemaDraft07)
+}
+
+func (n NotType) marshalJSONSchemaDraft(action SchemaAction, draft JSONSchemaDraft) ([]byte, error) {
+	if n.Type == nil {
+		return json.Marshal(map[string]interface{}{"not": map[string]interface{}{}})
+	}
+
+	childJSON, err := marshalChildJSONSchema(n.Type, action, draft)
+	if err != nil {
+		return nil, err
+	}
+	if childJSON == nil {
+		return nil, fmt.Errorf("%w: the negated schema exports no JSON Schema", ErrSchemaNotJSONSchemaMarshalable)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"not": json.RawMessage(childJSON),
+	})
+}
+
+func (n NotType) Lint(path string) []LintFinding {
+	if n.Type == nil {
+		return []LintFinding{NewLintFinding(path, LintSeverityError, "nil-not-type", "no schema to negate set, no data can be validated")}
+	}
+	return nil
+}
@@ -2,19 +2,80 @@ package pongo
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 )
 
+var ErrMissingRequiredProperty = errors.New("missing required property")
+var ErrReadOnlyProperty = errors.New("read-only property")
+var ErrWriteOnlyProperty = errors.New("write-only property")
+
+// AccessPolicy define how ObjectType handles the ReadOnly properties on SchemaActionParse
+// and the WriteOnly properties on SchemaActionSerialize
+type AccessPolicy string
+
+const (
+	// AccessPolicyStrip removes the property from the processed data, it is the default AccessPolicy
+	AccessPolicyStrip AccessPolicy = "strip"
+	// AccessPolicyReject reports an error at the property path
+	AccessPolicyReject AccessPolicy = "reject"
+)
+
+// ObjectType SchemaType validates an object whose properties are validated by the SchemaMap SchemaNode(s).
+// ObjectType keeps the properties declaration order, which is used to process the data (so the errors are always
+// reported in the same order), to marshal the pongo schema and to export the JSON Schema, see Keys.
+// Required keys are required on every action, RequiredByAction keys only on the given action;
+// every missing required key is reported as a SchemaElementError at the key path wrapping ErrMissingRequiredProperty.
+// ReadOnly keys (e.g. server generated ids) are not accepted on SchemaActionParse and WriteOnly keys (e.g. secrets)
+// are not emitted on SchemaActionSerialize, they are stripped or rejected according to AccessPolicy
+// and they are never required on that action
 type ObjectType struct {
-	SchemaMap `json:"properties"`
-	Required  []string `json:"required,omitempty"`
+	SchemaMap        `json:"properties"`
+	Required         []string                  `json:"required,omitempty"`
+	RequiredByAction map[SchemaAction][]string `json:"requiredByAction,omitempty"`
+	ReadOnly         []string                  `json:"readOnly,omitempty"`
+	WriteOnly        []string                  `json:"writeOnly,omitempty"`
+	AccessPolicy     AccessPolicy              `json:"accessPolicy,omitempty"`
+	// Workers overrides ProcessOptions.Workers for this ObjectType, it is a runtime setting and it is not marshalled
+	Workers int `json:"-"`
+
+	// order is the properties declaration order
+	order []string
+}
+
+// ObjectProperty is a property of an ObjectType, see OrderedObject
+type ObjectProperty struct {
+	Key    string
+	Schema SchemaType
+}
+
+func Property(key string, schema SchemaType) ObjectProperty {
+	return ObjectProperty{Key: key, Schema: schema}
 }
 
+// Object return a new ObjectType, since O is a map the properties are declared in alphabetical order
 func Object(properties O) *ObjectType {
+	var order []string
+	for key := range properties {
+		order = append(order, key)
+	}
+	sort.Strings(order)
+
 	return &ObjectType{
 		SchemaMap: properties.SchemaMap(),
 		Required:  []string{},
+		order:     order,
+	}
+}
+
+// OrderedObject return a new ObjectType with the properties declared in the given order
+func OrderedObject(properties ...ObjectProperty) *ObjectType {
+	o := Object(nil)
+	for _, p := range properties {
+		o = o.SetProperty(p.Key, p.Schema)
 	}
+	return o
 }
 
 func (o ObjectType) Process(action SchemaAction, dataPointer *DataPointer) (data Data, err error) {
@@ -25,26 +86,65 @@ func (o ObjectType) Process(action SchemaAction, dataPointer *DataPointer) (data
 
 	var schemaError = NewSchemaError()
 
-	// check required keys
-	if len(o.Required) > 0 {
-		if diff := ListMapDiff[string](o.Required, d); len(diff) > 0 {
-			return nil, NewSchemaError().Append(
-				dataPointer.Path(),
-				fmt.Errorf(
-					"cannot validate data as ObjectType at %s, missing required properties %v", dataPointer.Path(),
-					diff,
-				),
-			)
+	// check required keys, the present keys are validated anyway so all the errors are reported at once
+	for _, key := range ListMapDiff[string](o.RequiredKeys(action), d) {
+		path := dataPointer.Path().Push(o.SchemaMap[key], nil, key)
+		schemaError = schemaError.Append(*path, fmt.Errorf("cannot %s data as ObjectType at %s, %w %s", action, dataPointer.Path(), ErrMissingRequiredProperty, key))
+	}
+
+	// the keys not accessible on action are stripped or rejected
+	var policy = o.AccessPolicy
+	if policy == "" {
+		policy = AccessPolicyStrip
+	}
+	if policy != AccessPolicyStrip && policy != AccessPolicyReject {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as ObjectType at %s, unknown access policy %s", action, dataPointer.Path(), policy))
+	}
+	var inaccessible = map[string]struct{}{}
+	for _, key := range o.Keys() {
+		if _, ok := d[key]; !ok {
+			continue
+		}
+		if err := o.accessError(action, key); err != nil {
+			inaccessible[key] = struct{}{}
+			if policy == AccessPolicyReject {
+				path := dataPointer.Path().Push(o.SchemaMap[key], d[key], key)
+				schemaError = schemaError.Append(*path, fmt.Errorf("cannot %s data as ObjectType at %s, %w %s", action, dataPointer.Path(), err, key))
+			}
 		}
 	}
 
-	var processedObject = map[string]interface{}{}
+	// process the keys in the declaration order and then the unknown keys in alphabetical order,
+	// so the errors are always reported in the same order
+	var keys, unknownKeys []string
+	for _, key := range o.Keys() {
+		if _, ok := inaccessible[key]; ok {
+			continue
+		}
+		if _, ok := d[key]; ok {
+			keys = append(keys, key)
+		}
+	}
 	for key := range d {
+		if _, ok := o.SchemaMap[key]; !ok {
+			unknownKeys = append(unknownKeys, key)
+		}
+	}
+	sort.Strings(unknownKeys)
+	keys = append(keys, unknownKeys...)
+
+	workers := dataPointer.Options().Workers
+	if o.Workers > 0 {
+		workers = o.Workers
+	}
+
+	results, errs, err := processChildren(dataPointer.Options(), workers, len(keys), func(i int) (Data, error) {
+		key := keys[i]
+
 		// load BaseSchemaType, run all pre-checks related to ObjectType
 		schemaNode, ok := o.SchemaMap[key]
 		if !ok {
-			schemaError = schemaError.Append(dataPointer.Path(), fmt.Errorf("cannot validate data as ObjectType at %s, cannot get key %s", dataPointer.Path(), key))
-			continue
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot validate data as ObjectType at %s, cannot get key %s", dataPointer.Path(), key))
 		}
 
 		// navigate the DataPointer
@@ -52,15 +152,23 @@ func (o ObjectType) Process(action SchemaAction, dataPointer *DataPointer) (data
 
 		switch action {
 		case SchemaActionSerialize:
-			processedObject[key], err = schemaNode.Serialize(ptr)
+			return schemaNode.Serialize(ptr)
 		case SchemaActionParse:
-			processedObject[key], err = schemaNode.Parse(ptr)
+			return schemaNode.Parse(ptr)
 		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as ObjectType at %s: %w", action, dataPointer.Path(), err))
+	}
 
-		if err != nil {
-			schemaError = schemaError.MergeWithCast(dataPointer.Path(), err)
+	var processedObject = map[string]interface{}{}
+	for i, key := range keys {
+		if errs[i] != nil {
+			schemaError = schemaError.MergeWithCast(dataPointer.Path(), errs[i])
 			continue
 		}
+		processedObject[key] = results[i]
 	}
 
 	if len(schemaError.Errors) > 0 {
@@ -70,27 +178,204 @@ func (o ObjectType) Process(action SchemaAction, dataPointer *DataPointer) (data
 	return processedObject, nil
 }
 
+// Keys return the properties keys in declaration order,
+// the keys added directly to SchemaMap are returned last in alphabetical order
+func (o ObjectType) Keys() []string {
+	var keys []string
+	var declared = map[string]struct{}{}
+
+	for _, key := range o.order {
+		if _, ok := o.SchemaMap[key]; !ok {
+			continue
+		}
+		if _, ok := declared[key]; ok {
+			continue
+		}
+		declared[key] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	var undeclared []string
+	for key := range o.SchemaMap {
+		if _, ok := declared[key]; !ok {
+			undeclared = append(undeclared, key)
+		}
+	}
+	sort.Strings(undeclared)
+
+	return append(keys, undeclared...)
+}
+
+// SetProperty add a property after the already declared ones, or replace it keeping its position
+func (o ObjectType) SetProperty(key string, schema SchemaType) *ObjectType {
+	schemaMap := SchemaMap{}
+	for k, v := range o.SchemaMap {
+		schemaMap[k] = v
+	}
+	if schema != nil {
+		schemaMap[key] = Schema(schema)
+	} else {
+		schemaMap[key] = nil
+	}
+
+	order := o.Keys()
+	if _, ok := o.SchemaMap[key]; !ok {
+		order = append(order, key)
+	}
+
+	o.SchemaMap = schemaMap
+	o.order = order
+	return &o
+}
+
+func (o *ObjectType) Children() SchemaList {
+	list := SchemaList{}
+	for _, key := range o.Keys() {
+		list = append(list, o.SchemaMap[key])
+	}
+	return list
+}
+
+// MarshalJSON marshal the ObjectType keeping the properties declaration order
+func (o ObjectType) MarshalJSON() ([]byte, error) {
+	type objectType ObjectType
+
+	properties := newDocumentObject()
+	for _, key := range o.Keys() {
+		properties.Set(key, o.SchemaMap[key])
+	}
+
+	return json.Marshal(struct {
+		objectType
+		Properties *documentObject `json:"properties"`
+	}{
+		objectType: objectType(o),
+		Properties: properties,
+	})
+}
+
+// UnmarshalJSON unmarshal the ObjectType, the properties are declared in the same order of the JSON document
+func (o *ObjectType) UnmarshalJSON(b []byte) error {
+	type objectType ObjectType
+	var properties struct {
+		Properties json.RawMessage `json:"properties"`
+	}
+
+	if err := json.Unmarshal(b, (*objectType)(o)); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, &properties); err != nil {
+		return err
+	}
+
+	order, err := jsonObjectKeys(properties.Properties)
+	if err != nil {
+		return err
+	}
+	o.order = order
+
+	return nil
+}
+
+func (o ObjectType) SetWorkers(workers int) *ObjectType {
+	o.Workers = workers
+	return &o
+}
+
 func (o ObjectType) Require(requires ...string) *ObjectType {
 	o.Required = requires
 	return &o
 }
 
+// RequireOnAction set the keys required only when the data is processed with action
+func (o ObjectType) RequireOnAction(action SchemaAction, requires ...string) *ObjectType {
+	requiredByAction := map[SchemaAction][]string{}
+	for k, v := range o.RequiredByAction {
+		requiredByAction[k] = v
+	}
+	requiredByAction[action] = requires
+
+	o.RequiredByAction = requiredByAction
+	return &o
+}
+
+// RequiredKeys return the keys required when the data is processed with action,
+// Required keys first and then the RequiredByAction ones, without duplicates and without the keys
+// not accessible on action (ReadOnly on SchemaActionParse, WriteOnly on SchemaActionSerialize)
+func (o ObjectType) RequiredKeys(action SchemaAction) []string {
+	var keys []string
+	var seen = map[string]struct{}{}
+
+	for _, key := range append(append([]string{}, o.Required...), o.RequiredByAction[action]...) {
+		if _, ok := seen[key]; ok || o.accessError(action, key) != nil {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// SetReadOnly set the keys that are not accepted on SchemaActionParse
+func (o ObjectType) SetReadOnly(keys ...string) *ObjectType {
+	o.ReadOnly = keys
+	return &o
+}
+
+// SetWriteOnly set the keys that are not emitted on SchemaActionSerialize
+func (o ObjectType) SetWriteOnly(keys ...string) *ObjectType {
+	o.WriteOnly = keys
+	return &o
+}
+
+func (o ObjectType) SetAccessPolicy(policy AccessPolicy) *ObjectType {
+	o.AccessPolicy = policy
+	return &o
+}
+
+// accessError return ErrReadOnlyProperty or ErrWriteOnlyProperty if key is not accessible on action
+func (o ObjectType) accessError(action SchemaAction, key string) error {
+	switch action {
+	case SchemaActionParse:
+		if ListContains(o.ReadOnly, key) {
+			return ErrReadOnlyProperty
+		}
+	case SchemaActionSerialize:
+		if ListContains(o.WriteOnly, key) {
+			return ErrWriteOnlyProperty
+		}
+	}
+	return nil
+}
+
 func (o *ObjectType) SchemaTypeID() string {
 	return "object"
 }
 
 func (o ObjectType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
-	var childrenJSON = map[string]json.RawMessage{}
+	return o.marshalJSONSchemaDraft(action, JSONSchemaDraft07)
+}
+
+func (o ObjectType) marshalJSONSchemaDraft(action SchemaAction, draft JSONSchemaDraft) ([]byte, error) {
+	var childrenJSON = newDocumentObject()
 
-	for key, child := range o.SchemaMap {
-		j, err := MarshalJSONSchema(child, action)
+	for _, key := range o.Keys() {
+		child := o.SchemaMap[key]
+		if child == nil {
+			continue
+		}
+		j, err := marshalChildJSONSchema(child, action, draft)
 		if err != nil {
 			return nil, err
 		}
 		if j == nil {
 			continue
 		}
-		childrenJSON[key] = j
+		if j, err = o.annotateAccessJSONSchema(key, j); err != nil {
+			return nil, err
+		}
+		childrenJSON.Set(key, json.RawMessage(j))
 	}
 
 	jsonObject := map[string]interface{}{
@@ -99,9 +384,80 @@ func (o ObjectType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
 		"type":                 "object",
 	}
 
-	if len(o.Required) > 0 {
-		jsonObject["required"] = o.Required
+	if required := o.RequiredKeys(action); len(required) > 0 {
+		jsonObject["required"] = required
 	}
 
 	return json.Marshal(jsonObject)
 }
+
+// annotateAccessJSONSchema add the readOnly and writeOnly annotations to the JSON Schema of the key property
+func (o ObjectType) annotateAccessJSONSchema(key string, j []byte) ([]byte, error) {
+	readOnly, writeOnly := ListContains(o.ReadOnly, key), ListContains(o.WriteOnly, key)
+	if !readOnly && !writeOnly {
+		return j, nil
+	}
+
+	document, err := decodeDocument(j)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := document.(*documentObject)
+	if !ok {
+		return j, nil
+	}
+	if readOnly {
+		object.Set("readOnly", true)
+	}
+	if writeOnly {
+		object.Set("writeOnly", true)
+	}
+
+	return encodeDocument(object)
+}
+
+func (o ObjectType) Lint(path string) (findings []LintFinding) {
+	var seen = map[string]struct{}{}
+
+	for _, key := range o.Required {
+		if _, ok := seen[key]; ok {
+			findings = append(findings, NewLintFinding(path, LintSeverityWarning, "duplicate-required", "required property %s is listed more than once", key))
+			continue
+		}
+		seen[key] = struct{}{}
+
+		if _, ok := o.SchemaMap[key]; !ok {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "unknown-required", "required property %s is not defined in the object properties", key))
+		}
+	}
+
+	var actions []string
+	for action := range o.RequiredByAction {
+		actions = append(actions, string(action))
+	}
+	sort.Strings(actions)
+
+	for _, action := range actions {
+		for _, key := range o.RequiredByAction[SchemaAction(action)] {
+			if _, ok := o.SchemaMap[key]; !ok {
+				findings = append(findings, NewLintFinding(path, LintSeverityError, "unknown-required", "property %s required on %s is not defined in the object properties", key, action))
+			}
+		}
+	}
+
+	for _, key := range append(append([]string{}, o.ReadOnly...), o.WriteOnly...) {
+		if _, ok := o.SchemaMap[key]; !ok {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "unknown-access-property", "read-only or write-only property %s is not defined in the object properties", key))
+		}
+	}
+	for _, key := range o.ReadOnly {
+		if ListContains(o.WriteOnly, key) {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "contradictory-access", "property %s is both read-only and write-only", key))
+		}
+	}
+	if o.AccessPolicy != "" && o.AccessPolicy != AccessPolicyStrip && o.AccessPolicy != AccessPolicyReject {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-access-policy", "unknown access policy %s", o.AccessPolicy))
+	}
+
+	return findings
+}
@@ -50,10 +50,14 @@ func (e OneOfType) SchemaTypeID() string {
 }
 
 func (e OneOfType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
+	return e.marshalJSONSchemaDraft(action, JSONSchemaDraft07)
+}
+
+func (e OneOfType) marshalJSONSchemaDraft(action SchemaAction, draft JSONSchemaDraft) ([]byte, error) {
 	var childrenJSON []json.RawMessage
 
 	for _, child := range e.Children() {
-		j, err := MarshalJSONSchema(child, action)
+		j, err := marshalChildJSONSchema(child, action, draft)
 		if err != nil {
 			return nil, err
 		}
@@ -67,3 +71,14 @@ func (e OneOfType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
 		"oneOf": childrenJSON,
 	})
 }
+
+func (e OneOfType) Lint(path string) (findings []LintFinding) {
+	if len(e.SchemaList) == 0 {
+		return []LintFinding{NewLintFinding(path, LintSeverityError, "empty-combinator", "no elements set, no data can match exactly one element")}
+	}
+	for _, d := range lintDuplicateChildren(e.SchemaList) {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "duplicate-branch", "elements [%d] and [%d] are identical, data matching one of them will always match both", d[0], d[1]))
+	}
+
+	return findings
+}
@@ -2,14 +2,85 @@ package pongo
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+var ErrInvalidLengthUnit = errors.New("invalid length unit")
+
+// LengthUnit is the unit used by StringType to measure the string length
+type LengthUnit string
+
+const (
+	// LengthUnitBytes counts the bytes of the UTF-8 encoding, it is the default LengthUnit
+	LengthUnitBytes LengthUnit = "bytes"
+	// LengthUnitRunes counts the Unicode code points, as minLength and maxLength of JSON Schema
+	LengthUnitRunes LengthUnit = "runes"
+	// LengthUnitGraphemes counts the user-perceived characters (extended grapheme clusters),
+	// e.g. an emoji with a skin tone modifier or a flag is a single grapheme
+	LengthUnitGraphemes LengthUnit = "graphemes"
 )
 
+func (u LengthUnit) validate() error {
+	switch u {
+	case "", LengthUnitBytes, LengthUnitRunes, LengthUnitGraphemes:
+		return nil
+	}
+	return fmt.Errorf("%w %q", ErrInvalidLengthUnit, u)
+}
+
+// Len return the length of s measured in the LengthUnit
+func (u LengthUnit) Len(s string) int {
+	switch u {
+	case LengthUnitRunes:
+		return utf8.RuneCountInString(s)
+	case LengthUnitGraphemes:
+		return uniseg.GraphemeClusterCount(s)
+	}
+	return len(s)
+}
+
+func (u LengthUnit) String() string {
+	if u == "" {
+		return string(LengthUnitBytes)
+	}
+	return string(u)
+}
+
+func (u *LengthUnit) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("error decoding LengthUnit, got error: %w", err)
+	}
+	if err := LengthUnit(s).validate(); err != nil {
+		return err
+	}
+
+	*u = LengthUnit(s)
+	return nil
+}
+
+// StringType SchemaType validates a string.
+// Transforms are applied in order before the length checks, on all the actions unless TransformActions is set.
+// MinLen and MaxLen are measured in LengthUnit (bytes if not set).
+// Format is the name of a StringFormat (see RegisterStringFormat) checked after the length; if Convert is enabled,
+// on SchemaActionParse the string is converted to the Go value of the format (e.g. netip.Addr for "ipv4")
+// and on SchemaActionSerialize the Go value is converted back to a string
 type StringType struct {
-	Cast   *ActionFlagProperty  `json:"cast,omitempty"`
-	MinLen *NumberProperty[int] `json:"minLen,omitempty"`
-	MaxLen *NumberProperty[int] `json:"maxLen,omitempty"`
+	Cast       *ActionFlagProperty  `json:"cast,omitempty"`
+	MinLen     *NumberProperty[int] `json:"minLen,omitempty"`
+	MaxLen     *NumberProperty[int] `json:"maxLen,omitempty"`
+	LengthUnit LengthUnit           `json:"lengthUnit,omitempty"`
+
+	Transforms       []StringTransform   `json:"transforms,omitempty"`
+	TransformActions *ActionFlagProperty `json:"transformActions,omitempty"`
+
+	Format  string              `json:"format,omitempty"`
+	Convert *ActionFlagProperty `json:"convert,omitempty"`
 }
 
 func String() *StringType {
@@ -17,38 +88,79 @@ func String() *StringType {
 }
 
 func (s StringType) Process(action SchemaAction, dataPointer *DataPointer) (data Data, err error) {
+	var format StringFormat
+	if s.Format != "" {
+		if format, err = GetStringFormat(s.Format); err != nil {
+			return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as StringType at %s: %w", action, dataPointer.Path(), err))
+		}
+	}
+	convert := s.Format != "" && s.Convert.GetAction(action)
+
+	// on serialize, the Go values returned by the format conversion are converted back to strings
 	var str string
-	if s.Cast.GetAction(action) {
-		switch r := dataPointer.Get().(type) {
-		case string:
-			str = r
-		case int64:
-			str = strconv.FormatInt(r, 10)
-		case int:
-			str = strconv.FormatInt(int64(r), 10)
-		case float32:
-			str = strconv.FormatFloat(float64(r), 'f', -1, 32)
-		case float64:
-			str = strconv.FormatFloat(r, 'f', -1, 64)
-		case fmt.Stringer:
-			str = r.String()
-		default:
-			return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s cannot cast to \"String\"", dataPointer.Path()))
+	var formatted bool
+	if convert && action == SchemaActionSerialize && format.Format != nil {
+		str, formatted = format.Format(dataPointer.Get())
+	}
+
+	if !formatted {
+		if s.Cast.GetAction(action) {
+			switch r := dataPointer.Get().(type) {
+			case string:
+				str = r
+			case int64:
+				str = strconv.FormatInt(r, 10)
+			case int:
+				str = strconv.FormatInt(int64(r), 10)
+			case float32:
+				str = strconv.FormatFloat(float64(r), 'f', -1, 32)
+			case float64:
+				str = strconv.FormatFloat(r, 'f', -1, 64)
+			case fmt.Stringer:
+				str = r.String()
+			default:
+				return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s cannot cast to \"String\"", dataPointer.Path()))
+			}
+
+		} else {
+			var ok bool
+			str, ok = dataPointer.Get().(string)
+			if !ok {
+				return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s is not a \"String\"", dataPointer.Path()))
+			}
 		}
+	}
 
-	} else {
-		var ok bool
-		str, ok = dataPointer.Get().(string)
-		if !ok {
-			return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s is not a \"String\"", dataPointer.Path()))
+	if s.TransformActions == nil || s.TransformActions.GetAction(action) {
+		for _, t := range s.Transforms {
+			if str, err = t.Apply(str); err != nil {
+				return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %w", dataPointer.Path(), err))
+			}
 		}
 	}
 
-	if l, ok := s.MinLen.Get(); ok && l > len(str) {
-		return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s length is %d (min: %d)", dataPointer.Path(), len(str), l))
+	if err = s.LengthUnit.validate(); err != nil {
+		return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %w", dataPointer.Path(), err))
 	}
-	if l, ok := s.MaxLen.Get(); ok && l < len(str) {
-		return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s length is %d (Max: %d)", dataPointer.Path(), len(str), l))
+	length := s.LengthUnit.Len(str)
+	if l, ok := s.MinLen.Get(); ok && l > length {
+		return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s length is %d %s (min: %d)", dataPointer.Path(), length, s.LengthUnit, l))
+	}
+	if l, ok := s.MaxLen.Get(); ok && l < length {
+		return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s length is %d %s (Max: %d)", dataPointer.Path(), length, s.LengthUnit, l))
+	}
+
+	if s.Format != "" {
+		if err = format.Validate(str); err != nil {
+			return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s is not a valid %s: %w", dataPointer.Path(), s.Format, err))
+		}
+		if convert && action == SchemaActionParse && format.Parse != nil {
+			converted, err := format.Parse(str)
+			if err != nil {
+				return "", NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s cannot convert to %s: %w", dataPointer.Path(), s.Format, err))
+			}
+			return converted, nil
+		}
 	}
 
 	return str, nil
@@ -79,6 +191,38 @@ func (s StringType) SetMaxLen(i int) *StringType {
 	return &s
 }
 
+func (s StringType) SetFormat(format string) *StringType {
+	s.Format = format
+	return &s
+}
+
+func (s StringType) SetConvert(convert bool) *StringType {
+	s.Convert = s.Convert.Set(convert)
+	return &s
+}
+
+func (s StringType) SetConvertActions(actions ...SchemaAction) *StringType {
+	s.Convert = s.Convert.SetActions(actions...)
+	return &s
+}
+
+func (s StringType) SetLengthUnit(unit LengthUnit) *StringType {
+	s.LengthUnit = unit
+	return &s
+}
+
+// SetTransforms set the transforms applied in order to the string
+func (s StringType) SetTransforms(transforms ...StringTransform) *StringType {
+	s.Transforms = transforms
+	return &s
+}
+
+// SetTransformActions restrict the transforms to the given actions
+func (s StringType) SetTransformActions(actions ...SchemaAction) *StringType {
+	s.TransformActions = s.TransformActions.SetActions(actions...)
+	return &s
+}
+
 func (s *StringType) SchemaTypeID() string {
 	return "string"
 }
@@ -90,11 +234,33 @@ func (s StringType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
 
 	var jsonObject = map[string]interface{}{"type": "string"}
 
-	if l, ok := s.MinLen.Get(); ok {
-		jsonObject["minLength"] = l
+	// JSON Schema counts the code points: the bounds in the other units are exported only when a bound
+	// in code points holds for all the valid strings, so the JSON Schema never rejects a valid string
+	switch s.LengthUnit {
+	case LengthUnitRunes:
+		if l, ok := s.MinLen.Get(); ok {
+			jsonObject["minLength"] = l
+		}
+		if l, ok := s.MaxLen.Get(); ok {
+			jsonObject["maxLength"] = l
+		}
+	case LengthUnitGraphemes:
+		// a grapheme has at least one code point, but there is no limit to its code points
+		if l, ok := s.MinLen.Get(); ok {
+			jsonObject["minLength"] = l
+		}
+	default:
+		// a code point is encoded in 1 to 4 bytes
+		if l, ok := s.MinLen.Get(); ok {
+			jsonObject["minLength"] = (l + 3) / 4
+		}
+		if l, ok := s.MaxLen.Get(); ok {
+			jsonObject["maxLength"] = l
+		}
 	}
-	if l, ok := s.MaxLen.Get(); ok {
-		jsonObject["maxLength"] = l
+
+	if s.Format != "" {
+		jsonObject["format"] = s.Format
 	}
 
 	if !s.Cast.GetAction(action) {
@@ -110,3 +276,25 @@ func (s StringType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
 		},
 	})
 }
+
+func (s StringType) Lint(path string) []LintFinding {
+	findings := lintLength(path, s.MinLen, s.MaxLen)
+	if err := s.LengthUnit.validate(); err != nil {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-length-unit", "%s", err))
+	}
+	for _, t := range s.Transforms {
+		if err := t.validate(); err != nil {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-transform", "%s", err))
+		}
+	}
+	if s.Format != "" {
+		// a custom StringFormat can be registered after the schema is loaded, it is required only to process the data
+		format, err := GetStringFormat(s.Format)
+		if err != nil {
+			findings = append(findings, NewLintFinding(path, LintSeverityWarning, "unregistered-string-format", "%s", err))
+		} else if (s.Convert.Get() || len(s.Convert.GetActions()) > 0) && format.Parse == nil {
+			findings = append(findings, NewLintFinding(path, LintSeverityWarning, "useless-convert", "string format %s has no conversion", s.Format))
+		}
+	}
+	return findings
+}
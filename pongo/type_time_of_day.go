@@ -0,0 +1,130 @@
+package pongo
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TimeOfDayType SchemaType validates a CivilTime, which is returned on SchemaActionParse
+// and serialized as "HH:MM:SS[.fraction]" on SchemaActionSerialize.
+// With the cast enabled, the data can also be a "HH:MM:SS[.fraction]" or "HH:MM" string or a time.Time,
+// whose time of the day is taken in its location
+type TimeOfDayType struct {
+	Cast *ActionFlagProperty `json:"cast,omitempty"`
+	Min  *CivilTime          `json:"min,omitempty"`
+	Max  *CivilTime          `json:"max,omitempty"`
+}
+
+func TimeOfDay() *TimeOfDayType {
+	return &TimeOfDayType{}
+}
+
+func (t TimeOfDayType) cast(dataPointer *DataPointer) (CivilTime, error) {
+	switch r := dataPointer.Get().(type) {
+	case CivilTime:
+		return r, nil
+	case time.Time:
+		return CivilTimeOf(r), nil
+	case string:
+		v, err := ParseCivilTime(r)
+		if err != nil {
+			return CivilTime{}, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s cannot cast from string: %w", dataPointer.Path(), err))
+		}
+		return v, nil
+	}
+
+	return CivilTime{}, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s cannot cast to \"TimeOfDay\"", dataPointer.Path()))
+}
+
+func (t TimeOfDayType) Process(action SchemaAction, dataPointer *DataPointer) (data Data, err error) {
+	var v CivilTime
+
+	if action != SchemaActionParse && action != SchemaActionSerialize {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), NewErrInvalidAction(t, action))
+	}
+
+	if t.Cast.GetAction(action) {
+		if v, err = t.cast(dataPointer); err != nil {
+			return nil, err
+		}
+	} else {
+		var ok bool
+		v, ok = dataPointer.Get().(CivilTime)
+		if !ok {
+			return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s is not a pongo.CivilTime", dataPointer.Path()))
+		}
+	}
+
+	if !v.IsValid() {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s %s is not a valid time of day", dataPointer.Path(), v))
+	}
+	if t.Min != nil && v.Before(*t.Min) {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value is %s (Min: %s)", dataPointer.Path(), v, t.Min))
+	}
+	if t.Max != nil && v.After(*t.Max) {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("schema does not validate: %s value is %s (Max: %s)", dataPointer.Path(), v, t.Max))
+	}
+
+	if action == SchemaActionParse {
+		return v, nil
+	}
+	return v.String(), nil
+}
+
+func (t TimeOfDayType) SetCast(cast bool) *TimeOfDayType {
+	t.Cast = t.Cast.Set(cast)
+	return &t
+}
+
+func (t TimeOfDayType) SetCastActions(actions ...SchemaAction) *TimeOfDayType {
+	t.Cast = t.Cast.SetActions(actions...)
+	return &t
+}
+
+func (t TimeOfDayType) UnsetCastActions(actions ...SchemaAction) *TimeOfDayType {
+	t.Cast.UnsetActions(actions...)
+	return &t
+}
+
+func (t TimeOfDayType) SetMin(m CivilTime) *TimeOfDayType {
+	t.Min = &m
+	return &t
+}
+
+func (t TimeOfDayType) SetMax(m CivilTime) *TimeOfDayType {
+	t.Max = &m
+	return &t
+}
+
+func (t *TimeOfDayType) SchemaTypeID() string {
+	return "timeOfDay"
+}
+
+// MarshalJSONSchema return a pattern instead of the "time" format, which requires an UTC offset
+// that the times of day have not
+func (t TimeOfDayType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
+	switch action {
+	case SchemaActionParse:
+		if !t.Cast.GetAction(action) {
+			return nil, fmt.Errorf("%w: Cast must be enabled in order to JSONschema-marshal the type", ErrSchemaNotJSONSchemaMarshalable)
+		}
+		return json.Marshal(map[string]interface{}{"type": "string", "pattern": civilTimeInputPattern})
+	case SchemaActionSerialize:
+		return json.Marshal(map[string]interface{}{"type": "string", "pattern": civilTimeOutputPattern})
+	}
+
+	return nil, NewErrInvalidAction(t, action)
+}
+
+func (t TimeOfDayType) Lint(path string) (findings []LintFinding) {
+	if t.Min != nil && t.Max != nil && t.Min.After(*t.Max) {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "contradictory-range", "min %s is after max %s", t.Min, t.Max))
+	}
+	for _, b := range []*CivilTime{t.Min, t.Max} {
+		if b != nil && !b.IsValid() {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "invalid-bound", "%s is not a valid time of day", b))
+		}
+	}
+	return findings
+}
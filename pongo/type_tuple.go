@@ -0,0 +1,187 @@
+package pongo
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TupleType SchemaType validates fixed-shape lists, such as [lat, lon], where every position has its own SchemaNode.
+// By default all the Items are required and no additional item is allowed:
+// MinLen allows to make the trailing Items optional, Additional allows (and validates) the items after Items
+type TupleType struct {
+	Items      SchemaList           `json:"items"`
+	Additional *SchemaNode          `json:"additional,omitempty"`
+	MinLen     *NumberProperty[int] `json:"minLen,omitempty"`
+	MaxLen     *NumberProperty[int] `json:"maxLen,omitempty"`
+}
+
+func Tuple(items ...SchemaType) *TupleType {
+	return &TupleType{
+		Items: L(items).SchemaList(),
+	}
+}
+
+func (t TupleType) Process(action SchemaAction, dataPointer *DataPointer) (data Data, err error) {
+	var schemaError = NewSchemaError()
+
+	d, ok := dataPointer.Get().([]interface{})
+	if !ok {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as TupleType at %s, not an \"List\"", action, dataPointer.Path()))
+	}
+
+	// validate tuple length
+	if m := t.minLen(); m > len(d) {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as TupleType at %s, expected min length of the tuple at %d, got %d", action, dataPointer.Path(), m, len(d)))
+	}
+	if m, ok := t.maxLen(); ok && m < len(d) {
+		return nil, NewSchemaErrorWithError(dataPointer.Path(), fmt.Errorf("cannot %s data as TupleType at %s, expected max length of the tuple at %d, got %d", action, dataPointer.Path(), m, len(d)))
+	}
+
+	var processedSlice = []interface{}{}
+
+	for key := range d {
+		schemaNode := t.Additional
+		if key < len(t.Items) {
+			schemaNode = t.Items[key]
+		}
+
+		ptr := dataPointer.Push(schemaNode, d[key], fmt.Sprintf("[%d]", key))
+		if schemaNode == nil {
+			schemaError = schemaError.Append(ptr.Path(), fmt.Errorf("cannot %s data as TupleType at %s, no SchemaType set for the item", action, ptr.Path()))
+			continue
+		}
+
+		var item interface{}
+		switch action {
+		case SchemaActionSerialize:
+			item, err = schemaNode.Serialize(ptr)
+		case SchemaActionParse:
+			item, err = schemaNode.Parse(ptr)
+		}
+		processedSlice = append(processedSlice, item)
+
+		if err != nil {
+			schemaError = schemaError.MergeWithCast(dataPointer.Path(), err)
+			continue
+		}
+	}
+
+	if len(schemaError.Errors) > 0 {
+		return nil, schemaError
+	}
+
+	return processedSlice, nil
+}
+
+// minLen return the min length of the tuple, which is the number of Items if MinLen is not set
+func (t TupleType) minLen() int {
+	if m, ok := t.MinLen.Get(); ok {
+		return m
+	}
+	return len(t.Items)
+}
+
+// maxLen return the max length of the tuple, if any
+func (t TupleType) maxLen() (int, bool) {
+	m, ok := t.MaxLen.Get()
+	if t.Additional == nil && (!ok || m > len(t.Items)) {
+		return len(t.Items), true
+	}
+	return m, ok
+}
+
+func (t TupleType) SetAdditional(schema SchemaType) *TupleType {
+	if schema == nil {
+		t.Additional = nil
+	} else {
+		t.Additional = Schema(schema)
+	}
+	return &t
+}
+
+func (t TupleType) SetMinLen(i int) *TupleType {
+	t.MinLen = t.MinLen.Set(i)
+	return &t
+}
+
+func (t TupleType) SetMaxLen(i int) *TupleType {
+	t.MaxLen = t.MaxLen.Set(i)
+	return &t
+}
+
+func (t *TupleType) SchemaTypeID() string {
+	return "tuple"
+}
+
+func (t *TupleType) Children() SchemaList {
+	list := SchemaList{}
+	for _, item := range t.Items {
+		if item != nil {
+			list = append(list, item)
+		}
+	}
+	if t.Additional != nil {
+		list = append(list, t.Additional)
+	}
+	return list
+}
+
+// MarshalJSONSchema export the TupleType with the draft-07 "items" array and "additionalItems",
+// see MarshalJSONSchemaWithDraft for the draft 2020-12 "prefixItems"
+func (t TupleType) MarshalJSONSchema(action SchemaAction) ([]byte, error) {
+	return t.marshalJSONSchemaDraft(action, JSONSchemaDraft07)
+}
+
+func (t TupleType) marshalJSONSchemaDraft(action SchemaAction, draft JSONSchemaDraft) ([]byte, error) {
+	jsonObject := map[string]interface{}{
+		"type":     "array",
+		"minItems": t.minLen(),
+	}
+	if m, ok := t.MaxLen.Get(); ok {
+		jsonObject["maxItems"] = m
+	}
+
+	var itemsJSON = []json.RawMessage{}
+	for _, item := range t.Items {
+		var j json.RawMessage
+		if item != nil {
+			var err error
+			if j, err = marshalChildJSONSchema(item, action, draft); err != nil {
+				return nil, err
+			}
+		}
+		if j == nil {
+			j = json.RawMessage("{}")
+		}
+		itemsJSON = append(itemsJSON, j)
+	}
+	jsonObject["items"] = itemsJSON
+
+	if t.Additional == nil {
+		jsonObject["additionalItems"] = false
+	} else {
+		j, err := marshalChildJSONSchema(t.Additional, action, draft)
+		if err != nil {
+			return nil, err
+		}
+		if j != nil {
+			jsonObject["additionalItems"] = json.RawMessage(j)
+		}
+	}
+
+	return json.Marshal(jsonObject)
+}
+
+func (t TupleType) Lint(path string) []LintFinding {
+	findings := lintLength(path, t.MinLen, t.MaxLen)
+	if m, ok := t.MinLen.Get(); ok && t.Additional == nil && m > len(t.Items) {
+		findings = append(findings, NewLintFinding(path, LintSeverityError, "contradictory-length", "min length %d is greater than the %d items allowed without additional items", m, len(t.Items)))
+	}
+	for i, item := range t.Items {
+		if item == nil {
+			findings = append(findings, NewLintFinding(path, LintSeverityError, "nil-tuple-item", "no SchemaType set for the item [%d]", i))
+		}
+	}
+
+	return findings
+}
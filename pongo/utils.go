@@ -18,3 +18,12 @@ func ListMapDiff[T comparable](a []T, mb map[T]interface{}) []T {
 	}
 	return diff
 }
+
+func ListContains[T comparable](a []T, x T) bool {
+	for _, y := range a {
+		if y == x {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package pongo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrValidatorNotRegistered = errors.New("validator not registered")
+var ErrValidatorAlreadyRegistered = errors.New("validator already registered")
+
+// ValidatorFn is a custom rule run on the data after it has been processed by the SchemaNode SchemaType,
+// data is the output of the SchemaType and the returned Data replaces it, so a ValidatorFn can also transform the data
+type ValidatorFn func(action SchemaAction, data Data, dataPointer *DataPointer) (Data, error)
+
+// ValidatorFactory build a ValidatorFn from the JSON arguments of a SchemaValidator (nil if no args are set)
+type ValidatorFactory func(args json.RawMessage) (ValidatorFn, error)
+
+// SchemaValidator is a reference to a named validator registered in a PongoSchemaUnmarshalMapper.
+// Unlike DecoratedType, a SchemaValidator is serializable: it is marshalled as {"name": ..., "args": ...}
+// in the $validators of the SchemaNode and resolved again when the schema is unmarshalled
+type SchemaValidator struct {
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args,omitempty"`
+
+	fn ValidatorFn
+}
+
+// NewSchemaValidator return the SchemaValidator name resolved with the global PongoSchemaUnmarshalMapper,
+// args (if not nil) are marshalled to JSON and passed to the ValidatorFactory
+func NewSchemaValidator(name string, args any) (*SchemaValidator, error) {
+	var rawArgs json.RawMessage
+	if args != nil {
+		var err error
+		rawArgs, err = json.Marshal(args)
+		if err != nil {
+			return nil, fmt.Errorf("cannot marshal args of validator %s: %w", name, err)
+		}
+	}
+
+	return globalMapper().Validator(name, rawArgs)
+}
+
+func (v SchemaValidator) Process(action SchemaAction, data Data, dataPointer *DataPointer) (Data, error) {
+	if v.fn == nil {
+		return nil, fmt.Errorf("%w: validator %s has not been resolved", ErrValidatorNotRegistered, v.Name)
+	}
+	return v.fn(action, data, dataPointer)
+}
+
+// RegisterValidator register a ValidatorFactory with the given name, which should be namespaced
+// for custom validators (e.g. "acme.io/luhn"). An error is returned if the name is invalid or already registered
+func (p *PongoSchemaUnmarshalMapper) RegisterValidator(name string, factory ValidatorFactory) error {
+	if err := ValidateSchemaTypeID(name); err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, ok := p.validators[name]; ok {
+		return fmt.Errorf("%w: %s", ErrValidatorAlreadyRegistered, name)
+	}
+	p.validators[name] = factory
+
+	return nil
+}
+
+// Validator return the SchemaValidator name built with args,
+// an error is returned if name is not registered or the ValidatorFactory rejects args
+func (p *PongoSchemaUnmarshalMapper) Validator(name string, args json.RawMessage) (*SchemaValidator, error) {
+	p.mutex.RLock()
+	factory, ok := p.validators[name]
+	p.mutex.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrValidatorNotRegistered, name)
+	}
+
+	fn, err := factory(args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid args for validator %s: %w", name, err)
+	}
+
+	return &SchemaValidator{Name: name, Args: args, fn: fn}, nil
+}
+
+// Validators return the names of all the registered validators sorted by name
+func (p *PongoSchemaUnmarshalMapper) Validators() []string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	var names []string
+	for name := range p.validators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// RegisterValidator register a validator in the global PongoSchemaUnmarshalMapper, see PongoSchemaUnmarshalMapper.RegisterValidator
+func RegisterValidator(name string, factory ValidatorFactory) error {
+	return globalMapper().RegisterValidator(name, factory)
+}
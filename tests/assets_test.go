@@ -88,6 +88,28 @@ var testsSchemaMarshall = map[string]testSchemaMarshall{
 		).SetChain(true),
 		pongo.GlobalPongoSchemaUnmarshalMapper(),
 	},
+	"example-6": {
+		"example-6",
+		pongo.Object(pongo.O{
+			"payment": pongo.Discriminator("kind", pongo.O{
+				"card": pongo.Object(pongo.O{
+					"kind":   pongo.String(),
+					"number": pongo.String().SetMinLen(12).SetLengthUnit(pongo.LengthUnitRunes),
+				}).Require("kind", "number"),
+				"iban": pongo.Object(pongo.O{
+					"kind": pongo.String(),
+					"iban": pongo.String(),
+					"bic":  pongo.String(),
+				}).Require("kind", "iban"),
+			}),
+			"code": pongo.IfThenElse(
+				pongo.String().SetMaxLen(3).SetLengthUnit(pongo.LengthUnitRunes),
+				pongo.String().SetMinLen(2).SetLengthUnit(pongo.LengthUnitRunes),
+				pongo.String().SetMinLen(6).SetLengthUnit(pongo.LengthUnitRunes),
+			),
+		}).Require("payment"),
+		pongo.GlobalPongoSchemaUnmarshalMapper(),
+	},
 	"example-1_decorator": {
 		"example-1",
 		pongo.Object(pongo.O{
@@ -98,6 +120,30 @@ var testsSchemaMarshall = map[string]testSchemaMarshall{
 		}),
 		DecoratedSchemaUnmarshalMapper(),
 	},
+	"example-1_ordered": {
+		"example-1",
+		pongo.OrderedObject(
+			pongo.Property("aString", pongo.String()),
+			pongo.Property("aInt", pongo.Int().SetMin(10).SetCastActions(pongo.SchemaActionParse)),
+			pongo.Property("aDouble", pongo.Float64().SetMin(1).SetCast(true)),
+			pongo.Property("aBytes", pongo.Bytes().SetCast(true)),
+		),
+		pongo.GlobalPongoSchemaUnmarshalMapper(),
+	},
+	"example-3_ordered": {
+		"example-3",
+		pongo.OrderedObject(
+			pongo.Property("aString", pongo.String()),
+			pongo.Property("aList", pongo.List(pongo.AnyOf(
+				pongo.OrderedObject(
+					pongo.Property("aString", pongo.String().SetCast(true)),
+					pongo.Property("aBool", pongo.Bool()),
+				),
+				pongo.String(),
+			)).SetMinLen(1).SetMaxLen(3)),
+		).Require("aList"),
+		pongo.GlobalPongoSchemaUnmarshalMapper(),
+	},
 }
 
 func (t testSchemaMarshall) TestDirPath() string {
@@ -0,0 +1,13 @@
+// Package types define a SchemaType with the same name as the one in the other test package,
+// to check that the SchemaType IDs are resolved from the Go type and not from its name
+package types
+
+import "github.com/kael-k/pongo/v2/pongo"
+
+type Money struct {
+	Currency string `json:"currency"`
+}
+
+func (m Money) Process(_ pongo.SchemaAction, dataPointer *pongo.DataPointer) (pongo.Data, error) {
+	return dataPointer.Get(), nil
+}
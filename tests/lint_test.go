@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+type testLintCase struct {
+	desc   string
+	schema pongo.SchemaType
+	rules  []pongo.LintRule
+	want   []pongo.LintFinding
+}
+
+var testLintCases = []testLintCase{
+	{
+		desc: "lint-ok-1",
+		schema: pongo.Object(pongo.O{
+			"aString": pongo.String().SetMinLen(1).SetMaxLen(5),
+			"aList":   pongo.List(pongo.Int().SetMin(1).SetMax(2)),
+		}).Require("aString"),
+		want: nil,
+	},
+	{
+		desc:   "lint-string-ko-1",
+		schema: pongo.String().SetMinLen(10).SetMaxLen(5),
+		want: []pongo.LintFinding{
+			{Path: ".<string>", Severity: pongo.LintSeverityError, Rule: "contradictory-length", Message: "min length 10 is greater than max length 5"},
+		},
+	},
+	{
+		desc: "lint-object-ko-1",
+		schema: pongo.Object(pongo.O{
+			"aString": pongo.String(),
+			"aBytes":  pongo.Bytes().SetMinLen(3).SetMaxLen(1),
+		}).Require("aString", "aMissing"),
+		want: []pongo.LintFinding{
+			{Path: ".<object>", Severity: pongo.LintSeverityError, Rule: "unknown-required", Message: "required property aMissing is not defined in the object properties"},
+			{Path: ".<object>.aBytes<bytes>", Severity: pongo.LintSeverityError, Rule: "contradictory-length", Message: "min length 3 is greater than max length 1"},
+		},
+	},
+	{
+		desc: "lint-object-access-ko-1",
+		schema: pongo.Object(pongo.O{
+			"id": pongo.Int(),
+		}).SetReadOnly("id", "aMissing").SetWriteOnly("id").SetAccessPolicy("drop"),
+		want: []pongo.LintFinding{
+			{Path: ".<object>", Severity: pongo.LintSeverityError, Rule: "unknown-access-property", Message: "read-only or write-only property aMissing is not defined in the object properties"},
+			{Path: ".<object>", Severity: pongo.LintSeverityError, Rule: "contradictory-access", Message: "property id is both read-only and write-only"},
+			{Path: ".<object>", Severity: pongo.LintSeverityError, Rule: "invalid-access-policy", Message: "unknown access policy drop"},
+		},
+	},
+	{
+		desc:   "lint-datetime-ko-1",
+		schema: pongo.Datetime().SetAfter(time.Unix(1663800000, 0).UTC()).SetBefore(time.Unix(1663770000, 0).UTC()),
+		want: []pongo.LintFinding{
+			{Path: ".<datetime>", Severity: pongo.LintSeverityError, Rule: "contradictory-range", Message: "after 2022-09-21T22:40:00Z is later than before 2022-09-21T14:20:00Z"},
+		},
+	},
+	{
+		desc:   "lint-one-of-ko-1",
+		schema: pongo.OneOf(pongo.String(), pongo.Int(), pongo.String()),
+		want: []pongo.LintFinding{
+			{Path: ".<oneOf>", Severity: pongo.LintSeverityError, Rule: "duplicate-branch", Message: "elements [0] and [2] are identical, data matching one of them will always match both"},
+		},
+	},
+	{
+		desc:   "lint-list-ko-1",
+		schema: pongo.AllOf(pongo.List(nil), pongo.Decorate(pongo.Int().SetMin(3).SetMax(1))),
+		want: []pongo.LintFinding{
+			{Path: ".<allOf>.[0]<list>", Severity: pongo.LintSeverityError, Rule: "nil-list-type", Message: "no SchemaType set for the list items"},
+			{Path: ".<allOf>.[1]<int>", Severity: pongo.LintSeverityError, Rule: "contradictory-range", Message: "min 3 is greater than max 1"},
+		},
+	},
+	{
+		desc:   "lint-custom-rule-1",
+		schema: pongo.List(pongo.Schema(&TestDummySchemaType{})),
+		rules: []pongo.LintRule{
+			func(path string, schemaNode *pongo.SchemaNode) []pongo.LintFinding {
+				if _, ok := schemaNode.Type().(*TestDummySchemaType); ok {
+					return []pongo.LintFinding{pongo.NewLintFinding(path, pongo.LintSeverityWarning, "dummy", "dummy type in use")}
+				}
+				return nil
+			},
+		},
+		want: []pongo.LintFinding{
+			{Path: ".<list>.[*]<tests.TestDummySchemaType>", Severity: pongo.LintSeverityWarning, Rule: "dummy", Message: "dummy type in use"},
+		},
+	},
+}
+
+func TestLint(t *testing.T) {
+	for _, testCase := range testLintCases {
+		findings := pongo.Lint(testCase.schema, testCase.rules...)
+		if len(findings) != len(testCase.want) {
+			t.Errorf("test lint %s: expected %d finding(s), got %d: %v", testCase.desc, len(testCase.want), len(findings), findings)
+			continue
+		}
+		for i, finding := range findings {
+			if finding != testCase.want[i] {
+				t.Errorf("test lint %s: expected finding %s, got %s", testCase.desc, testCase.want[i], finding)
+			}
+		}
+	}
+}
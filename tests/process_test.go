@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+func testProcessData(n int) []interface{} {
+	var data []interface{}
+	for i := 0; i < n; i++ {
+		item := map[string]interface{}{"id": i, "name": fmt.Sprintf("item-%d", i)}
+		if i%100 == 0 {
+			item["name"] = i
+		}
+		data = append(data, item)
+	}
+	return data
+}
+
+func testProcessSchema() pongo.SchemaType {
+	return pongo.List(pongo.Object(pongo.O{
+		"id":   pongo.Int(),
+		"name": pongo.String().SetCast(true),
+	}))
+}
+
+func TestProcessWithOptionsWorkers(t *testing.T) {
+	data := testProcessData(1000)
+
+	want, err := pongo.Parse(testProcessSchema(), data)
+	if err != nil {
+		t.Errorf("unexpected error on sequential parse: %s", err)
+		return
+	}
+
+	got, err := pongo.ProcessWithOptions(testProcessSchema(), pongo.SchemaActionParse, data, pongo.ProcessOptions{Workers: 8})
+	if err != nil {
+		t.Errorf("unexpected error on parallel parse: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parallel parse output does not match the sequential one")
+	}
+
+	got, err = pongo.Parse(pongo.List(pongo.Object(pongo.O{
+		"id":   pongo.Int(),
+		"name": pongo.String().SetCast(true),
+	}).SetWorkers(2)).SetWorkers(4), data)
+	if err != nil {
+		t.Errorf("unexpected error on parallel parse with SchemaType workers: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parallel parse with SchemaType workers output does not match the sequential one")
+	}
+}
+
+func TestProcessWorkersNotMarshalled(t *testing.T) {
+	marshalled, err := pongo.MarshalPongoSchema(pongo.List(pongo.Object(pongo.O{"id": pongo.Int()}).SetWorkers(2)).SetWorkers(4))
+	if err != nil {
+		t.Errorf("unexpected error on marshal: %s", err)
+		return
+	}
+	if strings.Contains(string(marshalled), "workers") {
+		t.Errorf("expected the workers to not be marshalled, got %s", marshalled)
+	}
+}
+
+func TestProcessWithOptionsErrorsOrder(t *testing.T) {
+	schema := pongo.List(pongo.Object(pongo.O{
+		"id":   pongo.Int(),
+		"name": pongo.String(),
+	}))
+	data := testProcessData(1000)
+
+	_, want := pongo.Parse(schema, data)
+	if want == nil || len(want.(*pongo.SchemaError).Errors) != 10 {
+		t.Errorf("expected 10 errors on sequential parse, got %v", want)
+		return
+	}
+
+	for i := 0; i < 5; i++ {
+		_, err := pongo.ProcessWithOptions(schema, pongo.SchemaActionParse, data, pongo.ProcessOptions{Workers: 8})
+		if err == nil || err.Error() != want.Error() {
+			t.Errorf("expected the parallel parse errors to be the same of the sequential one, got %v", err)
+		}
+	}
+
+	for _, workers := range []int{0, 8} {
+		_, err := pongo.ProcessWithOptions(schema, pongo.SchemaActionParse, data, pongo.ProcessOptions{Workers: workers, FailFast: true})
+		schemaErr, ok := err.(*pongo.SchemaError)
+		if !ok || len(schemaErr.Errors) == 0 || len(schemaErr.Errors) > 8 {
+			t.Errorf("expected between 1 and 8 errors on fail fast parse with %d workers, got %v", workers, err)
+		}
+	}
+}
+
+func TestProcessWithOptionsContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, workers := range []int{0, 8} {
+		_, err := pongo.ProcessWithOptions(testProcessSchema(), pongo.SchemaActionParse, testProcessData(100), pongo.ProcessOptions{Context: ctx, Workers: workers})
+		schemaErr, ok := err.(*pongo.SchemaError)
+		if !ok || len(schemaErr.Errors) != 1 || !errors.Is(schemaErr.Errors[0].Error(), context.Canceled) {
+			t.Errorf("expected a context.Canceled error with %d workers, got %v", workers, err)
+		}
+	}
+}
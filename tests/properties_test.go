@@ -196,3 +196,33 @@ func TestTimeProperty(t *testing.T) {
 		t.Errorf("error unmarshall TestTimeProperty: expected ok == false instead ok == true")
 	}
 }
+
+func TestTimePropertyRelative(t *testing.T) {
+	var m *pongo.TimeProperty
+	now := time.Date(2024, time.March, 31, 12, 0, 0, 0, time.UTC)
+
+	m = m.SetRelative("-P1M")
+	if v, ok, err := m.GetAt(now); err != nil || !ok || !v.Equal(time.Date(2024, time.March, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("error on TestTimePropertyRelative, unexpected GetAt (%v, %v, %v)", v, ok, err)
+	}
+
+	marshaled, err := json.Marshal(m)
+	if err != nil || string(marshaled) != `{"relative":"-P1M"}` {
+		t.Errorf("error marshall TestTimePropertyRelative: got %s, %v", marshaled, err)
+	}
+	var unmarshall pongo.TimeProperty
+	if err = json.Unmarshal(marshaled, &unmarshall); err != nil {
+		t.Errorf("error unmarshall TestTimePropertyRelative: %s", err)
+	}
+	if period, ok := unmarshall.GetRelative(); !ok || period != "-P1M" {
+		t.Errorf("error unmarshall TestTimePropertyRelative: expected -P1M, got %s, %v", period, ok)
+	}
+	if err = json.Unmarshal([]byte(`{"relative": "90 days ago"}`), &unmarshall); err == nil {
+		t.Errorf("error unmarshall TestTimePropertyRelative: expected an error on an invalid duration")
+	}
+
+	m = m.Set(now)
+	if _, ok := m.GetRelative(); ok {
+		t.Errorf("error on TestTimePropertyRelative, expected Set to replace the relative duration")
+	}
+}
@@ -0,0 +1,185 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+	atypes "github.com/kael-k/pongo/v2/tests/internal/a/types"
+	btypes "github.com/kael-k/pongo/v2/tests/internal/b/types"
+)
+
+type TestMoneySchemaType struct {
+	Currency string `json:"currency"`
+}
+
+func (t TestMoneySchemaType) Process(_ pongo.SchemaAction, dataPointer *pongo.DataPointer) (pongo.Data, error) {
+	return dataPointer.Get(), nil
+}
+
+func init() {
+	err := pongo.RegisterSchemaType("acme.io/test-money", func() pongo.SchemaType { return &TestMoneySchemaType{} })
+	if err != nil {
+		panic(err)
+	}
+	if err = pongo.RegisterSchemaTypeAlias("acme.io/test-legacy-money", "acme.io/test-money"); err != nil {
+		panic(err)
+	}
+}
+
+func TestPongoSchemaUnmarshalMapperRegister(t *testing.T) {
+	mapper := pongo.NewPongoSchemaUnmarshalMapper()
+	factory := func() pongo.SchemaType { return &TestMoneySchemaType{} }
+
+	if err := mapper.Register("acme.io/money", factory); err != nil {
+		t.Errorf("unexpected error on Register: %s", err)
+	}
+	if err := mapper.Register("acme.io/money", factory); !errors.Is(err, pongo.ErrSchemaTypeAlreadyRegistered) {
+		t.Errorf("expected ErrSchemaTypeAlreadyRegistered on duplicate Register, got %v", err)
+	}
+	if err := mapper.RegisterAlias("money", "acme.io/money"); err != nil {
+		t.Errorf("unexpected error on RegisterAlias: %s", err)
+	}
+	if err := mapper.Register("money", factory); !errors.Is(err, pongo.ErrSchemaTypeAlreadyRegistered) {
+		t.Errorf("expected ErrSchemaTypeAlreadyRegistered on Register of an alias, got %v", err)
+	}
+	if err := mapper.RegisterAlias("cash", "acme.io/cash"); !errors.Is(err, pongo.ErrSchemaTypeNotRegistered) {
+		t.Errorf("expected ErrSchemaTypeNotRegistered on RegisterAlias of an unknown ID, got %v", err)
+	}
+
+	for _, id := range []string{"", "acme/money", "acme.io/", "a b", "ACME.io/money"} {
+		if err := mapper.Register(id, factory); !errors.Is(err, pongo.ErrInvalidSchemaTypeID) {
+			t.Errorf("expected ErrInvalidSchemaTypeID on Register of %q, got %v", id, err)
+		}
+	}
+
+	if _, ok := mapper.Get("money").(*TestMoneySchemaType); !ok {
+		t.Errorf("expected alias money to return a *TestMoneySchemaType")
+	}
+
+	types := mapper.Types()
+	if len(types) != 1 || types[0].ID != "acme.io/money" || !reflect.DeepEqual(types[0].Aliases, []string{"money"}) {
+		t.Errorf("unexpected registered types %v", types)
+	}
+
+	clone := mapper.Clone()
+	if err := clone.Register("acme.io/cash", factory); err != nil {
+		t.Errorf("unexpected error on Register: %s", err)
+	}
+	if mapper.Get("acme.io/cash") != nil {
+		t.Errorf("expected Register on a clone to not alter the original mapper")
+	}
+}
+
+func TestPongoSchemaUnmarshalMapperSet(t *testing.T) {
+	mapper := pongo.NewPongoSchemaUnmarshalMapper()
+	mapper.Set(func() pongo.SchemaType { return pongo.String() })
+
+	if mapper.Get("string") == nil {
+		t.Errorf("expected Set to register the SchemaType on the mapper")
+	}
+}
+
+func TestRegisterSchemaType(t *testing.T) {
+	if id := pongo.SchemaTypeID(&TestMoneySchemaType{}); id != "acme.io/test-money" {
+		t.Errorf("expected SchemaTypeID == \"acme.io/test-money\", got %s", id)
+	}
+
+	testSchemaMarshalEqual(t, pongo.List(&TestMoneySchemaType{Currency: "EUR"}), `{"$version":"1.1","$body":{"$type":"list","type":{"$type":"acme.io/test-money","currency":"EUR"}}}`)
+
+	_, _, err := pongo.UnmarshalPongoSchema([]byte(`{"$version": "1.1", "$body": {"$type": "acme.io/test-legacy-money"}}`))
+	if err != nil {
+		t.Errorf("unexpected error on unmarshal of an alias: %s", err)
+	}
+}
+
+type TestPointsSchemaType struct{}
+
+func (t TestPointsSchemaType) Process(_ pongo.SchemaAction, dataPointer *pongo.DataPointer) (pongo.Data, error) {
+	return dataPointer.Get(), nil
+}
+
+func TestMarshalPongoSchemaWithMapper(t *testing.T) {
+	mapper := pongo.GlobalPongoSchemaUnmarshalMapper()
+	if err := mapper.Register("acme.io/points", func() pongo.SchemaType { return &TestPointsSchemaType{} }); err != nil {
+		t.Errorf("unexpected error on Register: %s", err)
+		return
+	}
+
+	testSchemaMarshalEqualWithMapper(t, pongo.List(&TestPointsSchemaType{}), mapper, `{"$version":"1.1","$body":{"$type":"list","type":"acme.io/points"}}`)
+
+	marshalled, err := pongo.MarshalPongoSchemaWithMapper(&TestMoneySchemaType{}, mapper)
+	if err != nil {
+		t.Errorf("unexpected error on marshal: %s", err)
+		return
+	}
+	if !strings.Contains(string(marshalled), `"acme.io/test-money"`) {
+		t.Errorf("expected the types of the global mapper to keep their ID, got %s", marshalled)
+	}
+}
+
+func TestMarshalPongoSchemaWithMapperSameTypeName(t *testing.T) {
+	mapper := pongo.GlobalPongoSchemaUnmarshalMapper()
+	if err := mapper.Register("acme.io/money", func() pongo.SchemaType { return &atypes.Money{} }); err != nil {
+		t.Errorf("unexpected error on Register: %s", err)
+		return
+	}
+	if err := mapper.Register("other.io/money", func() pongo.SchemaType { return &btypes.Money{} }); err != nil {
+		t.Errorf("unexpected error on Register: %s", err)
+		return
+	}
+
+	schema := pongo.Object(pongo.O{
+		"a": pongo.Schema(&atypes.Money{Currency: "EUR"}),
+		"b": pongo.Schema(&btypes.Money{Currency: "USD"}),
+	})
+	// the types are registered in a map, so the marshal is repeated to catch an ID depending on the iteration order
+	for i := 0; i < 10; i++ {
+		testSchemaMarshalEqualWithMapper(t, schema, mapper, `{"$version":"1.1","$body":{"$type":"object","properties":{`+
+			`"a":{"$type":"acme.io/money","currency":"EUR"},"b":{"$type":"other.io/money","currency":"USD"}}}}`)
+	}
+
+	marshalled, err := pongo.MarshalPongoSchemaWithMapper(schema, mapper)
+	if err != nil || !strings.Contains(string(marshalled), `"acme.io/money"`) || !strings.Contains(string(marshalled), `"other.io/money"`) {
+		t.Errorf("expected the not compact schema to contain the IDs registered in the mapper, got %s, %v", marshalled, err)
+	}
+	if marshalled, _ = pongo.MarshalPongoSchema(schema); strings.Contains(string(marshalled), ".io/money") {
+		t.Errorf("expected the mapper to not alter the schema marshalled without it, got %s", marshalled)
+	}
+}
+
+func TestSchemaTypeIDNilGlobalMapper(t *testing.T) {
+	oldGlobalMap := pongo.GlobalPongoSchemaUnmarshalMapper()
+	defer pongo.SetGlobalPongoSchemaUnmarshalMapper(oldGlobalMap)
+
+	pongo.SetGlobalPongoSchemaUnmarshalMapper(nil)
+	if id := pongo.SchemaTypeID(&TestPointsSchemaType{}); id != "tests.TestPointsSchemaType" {
+		t.Errorf("expected SchemaTypeID == \"tests.TestPointsSchemaType\", got %s", id)
+	}
+}
+
+func TestPongoSchemaUnmarshalMapperConcurrency(t *testing.T) {
+	mapper := pongo.GlobalPongoSchemaUnmarshalMapper()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("acme.io/type-%d", i)
+			if err := mapper.Register(id, func() pongo.SchemaType { return pongo.String() }); err != nil {
+				t.Errorf("unexpected error on Register: %s", err)
+			}
+			if mapper.Get(id) == nil || mapper.Get("string") == nil {
+				t.Errorf("expected %s and string to be registered", id)
+			}
+			mapper.Types()
+			mapper.Clone()
+		}(i)
+	}
+	wg.Wait()
+}
@@ -27,7 +27,7 @@ func TestSchemasMarshallUnmarshall(t *testing.T) {
 			t.Errorf("error test schema unmarshall %s, error on unmarshall JSON: %s", testID, err)
 			continue
 		}
-		if !reflect.DeepEqual(schema, pongo.Schema(schemaType.wantSchema)) {
+		if !testSchemaEqual(schema, pongo.Schema(schemaType.wantSchema)) {
 			t.Errorf("error test schema unmarshall %s, unmarshalled schema does not match the wanted one", testID)
 			continue
 		}
@@ -121,3 +121,67 @@ func TestSetGlobalPongoSchemaUnmarshalMap(t *testing.T) {
 		t.Errorf("ok tests failed, error expected, got no one")
 	}
 }
+
+func TestSchemasMarshallUnmarshallCompact(t *testing.T) {
+	for testID, schemaType := range testsSchemaMarshall {
+		compactSchema, err := pongo.MarshalPongoSchemaWithOptions(schemaType.wantSchema, nil, pongo.PongoSchemaMarshalOptions{Compact: true})
+		if err != nil {
+			t.Errorf("error test compact schema %s, error on marshall: %s", testID, err)
+			continue
+		}
+
+		schema, _, err := pongo.UnmarshalPongoSchemaWithMapper(compactSchema, schemaType.typeMap)
+		if err != nil {
+			t.Errorf("error test compact schema %s, error on unmarshall: %s\n%s", testID, err, compactSchema)
+			continue
+		}
+		if !testSchemaEqual(schema, pongo.Schema(schemaType.wantSchema)) {
+			t.Errorf("error test compact schema %s, unmarshalled schema does not match the wanted one", testID)
+		}
+	}
+}
+
+func TestMarshalPongoSchemaCompact(t *testing.T) {
+	compactSchema, err := pongo.MarshalPongoSchemaWithOptions(pongo.Object(pongo.O{
+		"aString": pongo.String(),
+		"aList":   pongo.List(pongo.Int().SetMin(1)),
+		"aBool":   pongo.Schema(pongo.Bool()).SetMetadata("foo", "bar"),
+	}), nil, pongo.PongoSchemaMarshalOptions{Compact: true})
+	if err != nil {
+		t.Errorf("unexpected error on compact marshal: %s", err)
+		return
+	}
+
+	want := `{"$version":"1.1","$body":{"$type":"object","properties":{"aBool":{"$type":"bool","$metadata":{"foo":"bar"}},"aList":{"$type":"list","type":{"$type":"int","min":1}},"aString":"string"}}}`
+	if string(compactSchema) != want {
+		t.Errorf("expected compact schema %s, got %s", want, compactSchema)
+	}
+}
+
+func TestUnmarshalPongoSchemaShorthand(t *testing.T) {
+	schema, _, err := pongo.UnmarshalPongoSchema([]byte(`{
+		"$version": "1.0",
+		"$body": {
+			"$type": "object",
+			"properties": {
+				"name": "string",
+				"age": {"$type": "int", "min": 1},
+				"tags": {"$type": "list", "$body": {"type": "string", "maxLen": 3}}
+			},
+			"required": ["name"]
+		}
+	}`))
+	if err != nil {
+		t.Errorf("unexpected error on shorthand unmarshal: %s", err)
+		return
+	}
+
+	want := pongo.Object(pongo.O{
+		"name": pongo.String(),
+		"age":  pongo.Int().SetMin(1),
+		"tags": pongo.List(pongo.String()).SetMaxLen(3),
+	}).Require("name")
+	if !testSchemaEqual(schema, pongo.Schema(want)) {
+		t.Errorf("shorthand schema does not match the wanted one")
+	}
+}
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+func TestSchemasMarshallUnmarshallYAML(t *testing.T) {
+	for testID, schemaType := range testsSchemaMarshall {
+		_, metadata, err := schemaType.GetPongoSchema()
+		if err != nil {
+			t.Errorf("error test schema yaml %s, error on unmarshall JSON: %s", testID, err)
+			continue
+		}
+
+		yamlSchema, err := pongo.MarshalPongoSchemaYAMLWithMetadata(schemaType.wantSchema, metadata)
+		if err != nil {
+			t.Errorf("error test schema yaml %s, error on marshall YAML: %s", testID, err)
+			continue
+		}
+
+		schema, yamlMetadata, err := pongo.UnmarshalPongoSchemaYAMLWithMapper(yamlSchema, schemaType.typeMap)
+		if err != nil {
+			t.Errorf("error test schema yaml %s, error on unmarshall YAML: %s\n%s", testID, err, yamlSchema)
+			continue
+		}
+		if !testSchemaEqual(schema, pongo.Schema(schemaType.wantSchema)) {
+			t.Errorf("error test schema yaml %s, unmarshalled schema does not match the wanted one", testID)
+		}
+		if !reflect.DeepEqual(metadata, yamlMetadata) {
+			t.Errorf("error test schema yaml %s, expected metadata %v, got %v", testID, metadata, yamlMetadata)
+		}
+	}
+}
+
+const testYAMLSchema = `$version: "1.0"
+$metadata:
+  foo: bar
+$body:
+  $type: object
+  $body:
+    properties:
+      aString:
+        $type: string
+        $body:
+          cast: [PARSE]
+          maxLen: 5
+      aDatetime:
+        $type: datetime
+        $body:
+          before: 2025-08-01T09:00:00Z
+    required: [aString]
+`
+
+func TestUnmarshalPongoSchemaYAML(t *testing.T) {
+	schema, metadata, err := pongo.UnmarshalPongoSchemaYAML([]byte(testYAMLSchema))
+	if err != nil {
+		t.Errorf("unexpected error on YAML unmarshal: %s", err)
+		return
+	}
+
+	if v, ok := metadata.Get("foo"); !ok || v != "bar" {
+		t.Errorf("expected metadata foo == \"bar\", got [%v, %v]", v, ok)
+	}
+
+	object, ok := schema.Type().(*pongo.ObjectType)
+	if !ok {
+		t.Errorf("expected an *ObjectType, got %T", schema.Type())
+		return
+	}
+	if !reflect.DeepEqual(object.SchemaMap["aString"].Type(), pongo.String().SetCastActions(pongo.SchemaActionParse).SetMaxLen(5)) {
+		t.Errorf("unexpected aString schema %v", object.SchemaMap["aString"].Type())
+	}
+	if _, ok := object.SchemaMap["aDatetime"].Type().(*pongo.DatetimeType).Before.Get(); !ok {
+		t.Errorf("expected aDatetime Before to be set from a YAML timestamp")
+	}
+}
+
+var testBrokenYAMLSchemas = []struct {
+	desc string
+	yaml string
+	want string
+}{
+	{
+		desc: "unknown-type",
+		yaml: "$version: \"1.0\"\n$body:\n  $type: object\n  $body:\n    properties:\n      a:\n        $type: strin\n",
+		want: "line 7: ",
+	},
+	{
+		desc: "invalid-body",
+		yaml: "$version: \"1.0\"\n$body:\n  $type: object\n  $body:\n    properties:\n      a:\n        $type: int\n        $body:\n          min: abc\n",
+		want: "line 8: ",
+	},
+	{
+		desc: "no-type",
+		yaml: "$version: \"1.0\"\n$body:\n  $type: list\n  $body:\n    type:\n      $body: {}\n",
+		want: "line 6: ",
+	},
+	{
+		desc: "invalid-yaml",
+		yaml: "$version: \"1.0\"\n$body:\n  $type: [\n",
+		want: "yaml: line",
+	},
+}
+
+func TestBrokenPongoSchemasYAML(t *testing.T) {
+	for _, testCase := range testBrokenYAMLSchemas {
+		_, _, err := pongo.UnmarshalPongoSchemaYAML([]byte(testCase.yaml))
+		if err == nil {
+			t.Errorf("test broken yaml %s: expected error, got no one", testCase.desc)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), testCase.want) {
+			t.Errorf("test broken yaml %s: expected error starting with %q, got %q", testCase.desc, testCase.want, err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+const testPongoSchemaV1 = `{
+  "$version": "1.0",
+  "$body": {
+    "$type": "object",
+    "$body": {
+      "properties": {
+        "aBytes": {"$type": "bytes", "$body": {"mixLen": 3, "maxLen": 5}},
+        "aInlineBytes": {"$type": "bytes", "mixLen": 1},
+        "aString": {"$type": "string", "$body": {"minLen": 2}}
+      }
+    }
+  }
+}`
+
+func TestPongoSchemaUpgrade(t *testing.T) {
+	schema, _, err := pongo.UnmarshalPongoSchema([]byte(testPongoSchemaV1))
+	if err != nil {
+		t.Errorf("unexpected error on version 1.0 unmarshal: %s", err)
+		return
+	}
+
+	want := pongo.Object(pongo.O{
+		"aBytes":       pongo.Bytes().SetMinLen(3).SetMaxLen(5),
+		"aInlineBytes": pongo.Bytes().SetMinLen(1),
+		"aString":      pongo.String().SetMinLen(2),
+	})
+	if !reflect.DeepEqual(schema, pongo.Schema(want)) {
+		t.Errorf("upgraded schema does not match the wanted one")
+	}
+}
+
+func TestPongoSchemaDowngrade(t *testing.T) {
+	schema := pongo.Object(pongo.O{
+		"aBytes": pongo.Bytes().SetMinLen(3),
+	})
+
+	marshalled, err := pongo.MarshalPongoSchemaWithOptions(schema, nil, pongo.PongoSchemaMarshalOptions{Version: "1.0"})
+	if err != nil {
+		t.Errorf("unexpected error on version 1.0 marshal: %s", err)
+		return
+	}
+
+	want := `{"$version":"1.0","$body":{"$type":"object","$body":{"properties":{"aBytes":{"$type":"bytes","$body":{"mixLen":3}}}}}}`
+	if string(marshalled) != want {
+		t.Errorf("expected downgraded schema %s, got %s", want, marshalled)
+	}
+
+	unmarshalled, _, err := pongo.UnmarshalPongoSchema(marshalled)
+	if err != nil {
+		t.Errorf("unexpected error on version 1.0 unmarshal: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(unmarshalled, pongo.Schema(schema)) {
+		t.Errorf("downgraded and upgraded schema does not match the original one")
+	}
+}
+
+func TestPongoSchemaUnsupportedVersion(t *testing.T) {
+	for _, version := range []string{"9.0", "1.9", "1", "a.b"} {
+		_, _, err := pongo.UnmarshalPongoSchema([]byte(`{"$version": "` + version + `", "$body": "string"}`))
+		if !errors.Is(err, pongo.ErrPongoSchemaVersion) {
+			t.Errorf("expected ErrPongoSchemaVersion on version %s, got %v", version, err)
+		}
+	}
+
+	_, _, err := pongo.UnmarshalPongoSchema([]byte(`{"$version": "2.0", "$body": "string"}`))
+	if err == nil || !strings.Contains(err.Error(), "newer than the latest supported version") {
+		t.Errorf("expected newer version error, got %v", err)
+	}
+
+	_, err = pongo.MarshalPongoSchemaWithOptions(pongo.String(), nil, pongo.PongoSchemaMarshalOptions{Version: "0.1"})
+	if !errors.Is(err, pongo.ErrPongoSchemaVersion) {
+		t.Errorf("expected ErrPongoSchemaVersion on marshal version 0.1, got %v", err)
+	}
+}
+
+func TestRegisterPongoSchemaMigration(t *testing.T) {
+	err := pongo.RegisterPongoSchemaMigration(pongo.PongoSchemaMigration{From: "1.1", To: "1.2"})
+	if !errors.Is(err, pongo.ErrPongoSchemaVersion) {
+		t.Errorf("expected ErrPongoSchemaVersion on migration from an unknown version, got %v", err)
+	}
+
+	// the migration only affects the test SchemaType, so it does not alter other tests
+	err = pongo.RegisterPongoSchemaMigration(pongo.RenameBodyFieldMigration("1.0", "1.1", "tests.TestMigrationSchemaType", "old", "value"))
+	if err != nil {
+		t.Errorf("unexpected error registering migration: %s", err)
+		return
+	}
+
+	mapper := pongo.GlobalPongoSchemaUnmarshalMapper().Set(func() pongo.SchemaType { return &TestMigrationSchemaType{} })
+	schema, _, err := pongo.UnmarshalPongoSchemaWithMapper([]byte(`{"$version": "1.0", "$body": {"$type": "tests.TestMigrationSchemaType", "old": "foo"}}`), mapper)
+	if err != nil {
+		t.Errorf("unexpected error on migrated unmarshal: %s", err)
+		return
+	}
+	if v := schema.Type().(*TestMigrationSchemaType).Value; v != "foo" {
+		t.Errorf("expected migrated field value == \"foo\", got %q", v)
+	}
+}
+
+type TestMigrationSchemaType struct {
+	Value string `json:"value"`
+}
+
+func (t TestMigrationSchemaType) Process(_ pongo.SchemaAction, dataPointer *pongo.DataPointer) (pongo.Data, error) {
+	return dataPointer.Get(), nil
+}
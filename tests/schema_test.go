@@ -4,6 +4,7 @@ package tests
 
 import (
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/kael-k/pongo/v2/pongo"
@@ -79,6 +80,160 @@ func testSchemaCheckTest(t *testing.T, testCase testSchemaCase, testData pongo.D
 	}
 }
 
+// testSchemaMarshal check that schema is marshalled in the compact form as want and that the unmarshalled schema
+// is marshalled back as want, the unmarshalled schema is returned (nil on error)
+func testSchemaMarshal(t *testing.T, schema pongo.SchemaType, want string) *pongo.SchemaNode {
+	return testSchemaMarshalWithMapper(t, schema, nil, want)
+}
+
+// testSchemaMarshalWithMapper works as testSchemaMarshal, marshalling and unmarshalling the schema with mapper
+// (the global one if nil)
+func testSchemaMarshalWithMapper(t *testing.T, schema pongo.SchemaType, mapper *pongo.PongoSchemaUnmarshalMapper, want string) *pongo.SchemaNode {
+	options := pongo.PongoSchemaMarshalOptions{Compact: true, Mapper: mapper}
+	if mapper == nil {
+		mapper = pongo.GlobalPongoSchemaUnmarshalMapper()
+	}
+
+	marshalled, err := pongo.MarshalPongoSchemaWithOptions(schema, nil, options)
+	if err != nil {
+		t.Errorf("unexpected error on marshal of %s: %s", want, err)
+		return nil
+	}
+	if string(marshalled) != want {
+		t.Errorf("expected marshalled schema %s, got %s", want, marshalled)
+	}
+
+	unmarshalled, _, err := pongo.UnmarshalPongoSchemaWithMapper(marshalled, mapper)
+	if err != nil {
+		t.Errorf("unexpected error on unmarshal of %s: %s", marshalled, err)
+		return nil
+	}
+	remarshalled, err := pongo.MarshalPongoSchemaWithOptions(unmarshalled, nil, options)
+	if err != nil || string(remarshalled) != want {
+		t.Errorf("expected the unmarshalled schema to be marshalled as %s, got %s, %v", want, remarshalled, err)
+	}
+	return unmarshalled
+}
+
+// testSchemaMarshalEqual works as testSchemaMarshal, also checking that the unmarshalled schema is equal to schema
+func testSchemaMarshalEqual(t *testing.T, schema pongo.SchemaType, want string) {
+	testSchemaMarshalEqualWithMapper(t, schema, nil, want)
+}
+
+// testSchemaMarshalEqualWithMapper works as testSchemaMarshalWithMapper, also checking that the unmarshalled schema
+// is equal to schema
+func testSchemaMarshalEqualWithMapper(t *testing.T, schema pongo.SchemaType, mapper *pongo.PongoSchemaUnmarshalMapper, want string) {
+	unmarshalled := testSchemaMarshalWithMapper(t, schema, mapper, want)
+	if unmarshalled != nil && !reflect.DeepEqual(unmarshalled, pongo.Schema(schema)) {
+		t.Errorf("unmarshalled schema %s does not match the original one", want)
+	}
+}
+
+var testObjectTypeType = reflect.TypeOf(pongo.ObjectType{})
+
+// testSchemaEqual works as reflect.DeepEqual, but the properties declaration order of an ObjectType of want
+// is compared only if it is not the alphabetical one, which is also the order of the properties of an Object(O{...})
+func testSchemaEqual(got, want interface{}) bool {
+	return testSchemaValueEqual(reflect.ValueOf(got), reflect.ValueOf(want))
+}
+
+func testSchemaValueEqual(got, want reflect.Value) bool {
+	if !got.IsValid() || !want.IsValid() {
+		return got.IsValid() == want.IsValid()
+	}
+	if got.Type() != want.Type() {
+		return false
+	}
+
+	switch want.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if got.IsNil() || want.IsNil() {
+			return got.IsNil() == want.IsNil()
+		}
+		return testSchemaValueEqual(got.Elem(), want.Elem())
+	case reflect.Struct:
+		if want.Type() == testObjectTypeType && want.CanInterface() && got.CanInterface() {
+			wantKeys := want.Interface().(pongo.ObjectType).Keys()
+			if !sort.StringsAreSorted(wantKeys) && !reflect.DeepEqual(got.Interface().(pongo.ObjectType).Keys(), wantKeys) {
+				return false
+			}
+		}
+		for i := 0; i < want.NumField(); i++ {
+			if want.Type() == testObjectTypeType && want.Type().Field(i).Name == "order" {
+				continue
+			}
+			if !testSchemaValueEqual(got.Field(i), want.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Map:
+		if got.IsNil() != want.IsNil() {
+			return false
+		}
+		if want.Kind() == reflect.Map {
+			if got.Len() != want.Len() {
+				return false
+			}
+			iter := want.MapRange()
+			for iter.Next() {
+				if !testSchemaValueEqual(got.MapIndex(iter.Key()), iter.Value()) {
+					return false
+				}
+			}
+			return true
+		}
+		fallthrough
+	case reflect.Array:
+		if got.Len() != want.Len() {
+			return false
+		}
+		for i := 0; i < want.Len(); i++ {
+			if !testSchemaValueEqual(got.Index(i), want.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Func:
+		return got.IsNil() && want.IsNil()
+	case reflect.Bool:
+		return got.Bool() == want.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return got.Int() == want.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return got.Uint() == want.Uint()
+	case reflect.Float32, reflect.Float64:
+		return got.Float() == want.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return got.Complex() == want.Complex()
+	case reflect.String:
+		return got.String() == want.String()
+	}
+	return got.Pointer() == want.Pointer()
+}
+
+// testSchemaJSONSchema check the JSON Schema exported by schema on every action of want
+func testSchemaJSONSchema(t *testing.T, schema pongo.SchemaType, want map[pongo.SchemaAction]string) {
+	for action, w := range want {
+		jsonSchema, err := pongo.MarshalJSONSchema(pongo.Schema(schema), action)
+		if err != nil || string(jsonSchema) != w {
+			t.Errorf("expected %s JSON Schema %s on %s, got %s, %v", pongo.SchemaTypeID(schema), w, action, jsonSchema, err)
+		}
+	}
+}
+
+// testSchemaLint check that the lint of schema returns findings with rules, in order
+func testSchemaLint(t *testing.T, schema pongo.SchemaType, rules ...string) {
+	findings := pongo.Lint(schema)
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Rule)
+	}
+	if len(got) != len(rules) || len(rules) > 0 && !reflect.DeepEqual(got, rules) {
+		t.Errorf("expected %s lint findings with rules %v, got %v", pongo.SchemaTypeID(schema), rules, findings)
+	}
+}
+
 func TestSchemaMetadata(t *testing.T) {
 	s := pongo.NewEmptySchema()
 	if v, ok := s.GetMetadata("foo"); ok {
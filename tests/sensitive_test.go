@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+func testSensitiveSchema() *pongo.ObjectType {
+	return pongo.OrderedObject(
+		pongo.Property("user", pongo.String()),
+		pongo.Property("password", pongo.Schema(pongo.String().SetMinLen(8)).SetSensitive(pongo.MaskRedact)),
+		pongo.Property("card", pongo.Schema(pongo.String()).SetSensitive(pongo.MaskLast4)),
+		pongo.Property("token", pongo.Schema(pongo.String()).SetSensitive(pongo.MaskHash)),
+	)
+}
+
+func TestSensitiveMask(t *testing.T) {
+	data := map[string]interface{}{"user": "john", "password": "hunter22", "card": "4111111111111111", "token": "abc"}
+
+	parsed, err := pongo.Parse(testSensitiveSchema(), data)
+	if err != nil {
+		t.Errorf("unexpected error on parse: %s", err)
+	} else if !reflect.DeepEqual(parsed, data) {
+		t.Errorf("expected the data not to be masked on parse, got %v", parsed)
+	}
+
+	serialized, err := pongo.Serialize(testSensitiveSchema(), data)
+	if err != nil {
+		t.Errorf("unexpected error on serialize: %s", err)
+		return
+	}
+	want := map[string]interface{}{
+		"user":     "john",
+		"password": pongo.RedactedValue,
+		"card":     "************1111",
+		"token":    "sha256:ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+	}
+	if !reflect.DeepEqual(serialized, want) {
+		t.Errorf("expected %v, got %v", want, serialized)
+	}
+
+	if masked, err := pongo.Serialize(pongo.Schema(pongo.String()).SetSensitive(pongo.MaskLast4), "1234"); err != nil || masked != "****" {
+		t.Errorf("expected short data to be fully masked, got %v, %v", masked, err)
+	}
+	if _, err = pongo.Serialize(pongo.Schema(pongo.String()).SetSensitive("drop"), "1234"); !errors.Is(err.(*pongo.SchemaError).Errors[0].Error(), pongo.ErrInvalidMask) {
+		t.Errorf("expected ErrInvalidMask, got %v", err)
+	}
+}
+
+func TestSensitiveErrors(t *testing.T) {
+	data := map[string]interface{}{"user": "john", "password": "secret", "card": 4111111111111111, "unknown": 1}
+
+	_, err := pongo.Parse(testSensitiveSchema(), data)
+	schemaErr, ok := err.(*pongo.SchemaError)
+	if !ok || len(schemaErr.Errors) != 3 {
+		t.Errorf("expected 3 errors, got %v", err)
+		return
+	}
+
+	for _, secret := range []string{"secret", "4111111111111111"} {
+		if strings.Contains(schemaErr.Error(), secret) {
+			t.Errorf("expected the error message not to contain %s, got %s", secret, schemaErr)
+		}
+	}
+	if !strings.Contains(schemaErr.Error(), "john") {
+		t.Errorf("expected the error message to contain the not sensitive data, got %s", schemaErr)
+	}
+
+	var sensitiveErr pongo.SensitiveError
+	if !errors.As(schemaErr.Errors[0].Error(), &sensitiveErr) {
+		t.Errorf("expected a SensitiveError, got %s", schemaErr.Errors[0].Error())
+	}
+	if schemaErr.Errors[0].Data() != pongo.RedactedValue {
+		t.Errorf("expected redacted data, got %v", schemaErr.Errors[0].Data())
+	}
+
+	marshalled, err := json.Marshal(schemaErr)
+	if err != nil {
+		t.Errorf("unexpected error on marshal: %s", err)
+		return
+	}
+	for _, secret := range []string{"secret", "4111111111111111"} {
+		if strings.Contains(string(marshalled), secret) {
+			t.Errorf("expected the JSON errors not to contain %s, got %s", secret, marshalled)
+		}
+	}
+
+	var decoded struct {
+		Errors []struct {
+			Path  string      `json:"path"`
+			Data  interface{} `json:"data"`
+			Error string      `json:"error"`
+		} `json:"errors"`
+	}
+	if err = json.Unmarshal(marshalled, &decoded); err != nil || len(decoded.Errors) != 3 {
+		t.Errorf("unexpected JSON errors %s", marshalled)
+		return
+	}
+	wantData := map[string]interface{}{"user": "john", "password": pongo.RedactedValue, "card": pongo.RedactedValue, "unknown": float64(1)}
+	if decoded.Errors[2].Path != ".<object>" || !reflect.DeepEqual(decoded.Errors[2].Data, wantData) {
+		t.Errorf("expected the object data to be redacted %v, got %v", wantData, decoded.Errors[2].Data)
+	}
+}
+
+func TestSensitiveExprErrors(t *testing.T) {
+	schema := pongo.AllOf(
+		pongo.Object(pongo.O{"pin": pongo.Schema(pongo.String()).SetSensitive(pongo.MaskRedact)}),
+		pongo.Expr("@.pin > 5"),
+		pongo.Expr("@.pin"),
+	)
+
+	_, err := pongo.Parse(schema, map[string]interface{}{"pin": "9876-secret"})
+	schemaErr, ok := err.(*pongo.SchemaError)
+	if !ok || len(schemaErr.Errors) != 2 {
+		t.Errorf("expected 2 errors, got %v", err)
+		return
+	}
+	if strings.Contains(schemaErr.Error(), "9876-secret") {
+		t.Errorf("expected the error message not to contain the sensitive data, got %s", schemaErr)
+	}
+	if !strings.Contains(schemaErr.Error(), "invalid operands of type string and float64 for operator >") {
+		t.Errorf("expected the error message to report the operand types, got %s", schemaErr)
+	}
+
+	marshalled, err := json.Marshal(schemaErr)
+	if err != nil {
+		t.Errorf("unexpected error on marshal: %s", err)
+		return
+	}
+	if strings.Contains(string(marshalled), "9876-secret") {
+		t.Errorf("expected the JSON errors not to contain the sensitive data, got %s", marshalled)
+	}
+}
+
+func TestSensitiveMarshal(t *testing.T) {
+	testSchemaMarshalEqual(t, testSensitiveSchema(), `{"$version":"1.1","$body":{"$type":"object","properties":{"user":"string","password":{"$type":"string","$sensitive":{"mask":"redact"},"minLen":8},"card":{"$type":"string","$sensitive":{"mask":"last4"}},"token":{"$type":"string","$sensitive":{"mask":"hash"}}}}}`)
+
+	_, _, err := pongo.UnmarshalPongoSchema([]byte(`{"$version": "1.1", "$body": {"$type": "string", "$sensitive": {"mask": "drop"}}}`))
+	if !errors.Is(err, pongo.ErrInvalidMask) {
+		t.Errorf("expected ErrInvalidMask on unmarshal, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"errors"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+func init() {
+	err := pongo.RegisterStringFormat("acme.io/test-sku", pongo.StringFormat{
+		Validate: func(s string) error {
+			if !strings.HasPrefix(s, "SKU-") {
+				return pongo.ErrFormatMismatch
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestStringFormat(t *testing.T) {
+	var cases = map[string]struct {
+		ok []string
+		ko []string
+	}{
+		pongo.StringFormatEmail:        {ok: []string{"john.doe@example.com"}, ko: []string{"john", "John <john@example.com>"}},
+		pongo.StringFormatURI:          {ok: []string{"https://example.com/a?b=c"}, ko: []string{"/relative", "http://[::1"}},
+		pongo.StringFormatURIReference: {ok: []string{"https://example.com", "/relative#a"}, ko: []string{"http://[::1"}},
+		pongo.StringFormatHostname:     {ok: []string{"example.com", "localhost"}, ko: []string{"-example.com", "exa mple.com", strings.Repeat("a", 64)}},
+		pongo.StringFormatIPv4:         {ok: []string{"192.168.0.1"}, ko: []string{"::1", "256.0.0.1"}},
+		pongo.StringFormatIPv6:         {ok: []string{"::1", "2001:db8::1"}, ko: []string{"192.168.0.1", "fe80::1%eth0"}},
+		pongo.StringFormatUUID:         {ok: []string{"123e4567-e89b-12d3-a456-426614174000"}, ko: []string{"123e4567e89b12d3a456426614174000"}},
+		pongo.StringFormatDate:         {ok: []string{"2024-02-29"}, ko: []string{"2023-02-29", "2024-2-1"}},
+		pongo.StringFormatTime:         {ok: []string{"14:05:00Z", "14:05:00.5+01:00"}, ko: []string{"14:05", "25:00:00Z"}},
+		pongo.StringFormatDuration:     {ok: []string{"P1Y2M10DT2H30M", "PT1.5S", "P2W"}, ko: []string{"P", "PT", "1D", "P1H"}},
+		"acme.io/test-sku":             {ok: []string{"SKU-1"}, ko: []string{"1"}},
+	}
+
+	for format, c := range cases {
+		schema := pongo.String().SetFormat(format)
+		for _, data := range c.ok {
+			if _, err := pongo.Parse(schema, data); err != nil {
+				t.Errorf("expected %s to be a valid %s, got %s", data, format, err)
+			}
+		}
+		for _, data := range c.ko {
+			_, err := pongo.Parse(schema, data)
+			if schemaErr, ok := err.(*pongo.SchemaError); !ok || !errors.Is(schemaErr.Errors[0].Error(), pongo.ErrFormatMismatch) {
+				t.Errorf("expected %s not to be a valid %s, got %v", data, format, err)
+			}
+		}
+	}
+
+	_, err := pongo.Parse(pongo.String().SetFormat("acme.io/test-missing"), "a")
+	if schemaErr, ok := err.(*pongo.SchemaError); !ok || !errors.Is(schemaErr.Errors[0].Error(), pongo.ErrStringFormatNotRegistered) {
+		t.Errorf("expected ErrStringFormatNotRegistered, got %v", err)
+	}
+	if err = pongo.RegisterStringFormat(pongo.StringFormatEmail, pongo.StringFormat{Validate: func(string) error { return nil }}); !errors.Is(err, pongo.ErrStringFormatAlreadyRegistered) {
+		t.Errorf("expected ErrStringFormatAlreadyRegistered, got %v", err)
+	}
+}
+
+func TestStringFormatConvert(t *testing.T) {
+	schema := pongo.String().SetFormat(pongo.StringFormatIPv4).SetConvert(true)
+
+	parsed, err := pongo.Parse(schema, "192.168.0.1")
+	if err != nil || parsed != netip.MustParseAddr("192.168.0.1") {
+		t.Errorf("expected a netip.Addr, got %#v, %v", parsed, err)
+	}
+	serialized, err := pongo.Serialize(schema, netip.MustParseAddr("192.168.0.1"))
+	if err != nil || serialized != "192.168.0.1" {
+		t.Errorf("expected the address to be serialized as a string, got %#v, %v", serialized, err)
+	}
+	if _, err = pongo.Serialize(schema, netip.MustParseAddr("::1")); err == nil {
+		t.Errorf("expected an IPv6 address not to be serialized as ipv4")
+	}
+
+	schema = pongo.String().SetFormat(pongo.StringFormatURI).SetConvertActions(pongo.SchemaActionParse)
+	parsed, err = pongo.Parse(schema, "https://example.com/a")
+	if u, ok := parsed.(*url.URL); err != nil || !ok || u.Host != "example.com" {
+		t.Errorf("expected a *url.URL, got %#v, %v", parsed, err)
+	}
+	if _, err = pongo.Serialize(schema, &url.URL{Scheme: "https", Host: "example.com"}); err == nil {
+		t.Errorf("expected the conversion to be disabled on serialize")
+	}
+}
+
+func TestStringFormatMarshal(t *testing.T) {
+	schema := pongo.String().SetFormat(pongo.StringFormatEmail).SetConvert(true)
+
+	testSchemaMarshalEqual(t, schema, `{"$version":"1.1","$body":{"$type":"string","format":"email","convert":true}}`)
+	testSchemaJSONSchema(t, schema, map[pongo.SchemaAction]string{pongo.SchemaActionParse: `{"format":"email","type":"string"}`})
+	testSchemaLint(t, schema, "useless-convert")
+	testSchemaLint(t, pongo.String().SetFormat("acme.io/test-missing"), "unregistered-string-format")
+}
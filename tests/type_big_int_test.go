@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+func testBigInt() *pongo.BigIntType {
+	return pongo.BigInt().SetOutput(pongo.NumberOutputString)
+}
+
+var testTypeBigIntCases = []testSchemaCase{
+	{
+		desc:   "type-big-int-ok-1",
+		schema: testBigInt(),
+		data:   func() pongo.Data { return json.Number("9007199254740993") },
+		want:   func() pongo.Data { return "9007199254740993" },
+	},
+	{
+		desc:   "type-big-int-ok-2",
+		schema: testBigInt(),
+		data:   func() pongo.Data { return json.Number("1e21") },
+		want:   func() pongo.Data { return "1000000000000000000000" },
+	},
+	{
+		desc:   "type-big-int-ok-3",
+		schema: testBigInt().SetCast(true),
+		data:   func() pongo.Data { return "-123456789012345678901234567890" },
+		want:   func() pongo.Data { return "-123456789012345678901234567890" },
+	},
+	{
+		desc:   "type-big-int-ok-4",
+		schema: testBigInt().SetCast(true),
+		data:   func() pongo.Data { return uint64(18446744073709551615) },
+		want:   func() pongo.Data { return "18446744073709551615" },
+	},
+	{
+		desc:   "type-big-int-ok-5",
+		schema: pongo.BigInt().SetOutput(pongo.NumberOutputNumber).SetCast(true).SetMin(big.NewInt(0)).SetMax(big.NewInt(100)),
+		data:   func() pongo.Data { return 100.0 },
+		want:   func() pongo.Data { return json.Number("100") },
+	},
+	{
+		desc:   "type-big-int-ok-6",
+		schema: testBigInt().SetCast(true),
+		data:   func() pongo.Data { return "+5" },
+		want:   func() pongo.Data { return "5" },
+	},
+	{
+		desc:   "type-big-int-ko-1",
+		schema: testBigInt(),
+		data:   func() pongo.Data { return 1 },
+		errors: 1,
+	},
+	{
+		desc:   "type-big-int-ko-2",
+		schema: testBigInt(),
+		data:   func() pongo.Data { return json.Number("1.5") },
+		errors: 1,
+	},
+	{
+		desc:   "type-big-int-ko-3",
+		schema: testBigInt().SetCast(true),
+		data:   func() pongo.Data { return 1.5 },
+		errors: 1,
+	},
+	{
+		desc:   "type-big-int-ko-4",
+		schema: testBigInt().SetMin(big.NewInt(0)).SetMax(big.NewInt(100)),
+		data:   func() pongo.Data { return json.Number("101") },
+		errors: 1,
+	},
+	{
+		desc:   "type-big-int-ko-5",
+		schema: testBigInt(),
+		data:   func() pongo.Data { return (*big.Int)(nil) },
+		errors: 1,
+	},
+	{
+		desc:   "type-big-int-ko-6",
+		schema: testBigInt().SetCast(true),
+		data:   func() pongo.Data { return "1e3" },
+		errors: 1,
+	},
+	{
+		desc:   "type-big-int-ko-7",
+		schema: testBigInt().SetCast(true),
+		data:   func() pongo.Data { return "1.0" },
+		errors: 1,
+	},
+}
+
+func TestTypeBigInt_Parse(t *testing.T) {
+	testSchemaCaseParse(testTypeBigIntCases)(t)
+}
+
+func TestTypeBigIntOutput(t *testing.T) {
+	id, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	parsed, err := pongo.Parse(pongo.BigInt(), json.Number(id.String()))
+	if i, ok := parsed.(*big.Int); err != nil || !ok || i.Cmp(id) != 0 {
+		t.Errorf("expected the *big.Int %s, got %#v, %v", id, parsed, err)
+	}
+	serialized, err := pongo.Serialize(pongo.BigInt(), id)
+	if err != nil || serialized != id.String() {
+		t.Errorf("expected the string %s, got %#v, %v", id, serialized, err)
+	}
+}
+
+func TestTypeBigIntNil(t *testing.T) {
+	schema := pongo.Object(pongo.O{"id": pongo.BigInt()})
+	if _, err := pongo.Serialize(schema, map[string]interface{}{"id": (*big.Int)(nil)}); err == nil {
+		t.Errorf("expected an error on serialize of a nil *big.Int")
+	}
+
+	bigInt := pongo.BigInt().SetMin(nil).SetMax(big.NewInt(1)).SetMax(nil)
+	if _, ok := bigInt.Min.Get(); ok {
+		t.Errorf("expected SetMin(nil) to leave Min unset")
+	}
+	if _, ok := bigInt.Max.Get(); ok {
+		t.Errorf("expected SetMax(nil) to unset Max")
+	}
+}
+
+func TestTypeBigIntMarshal(t *testing.T) {
+	schema := pongo.BigInt().SetCast(true).SetMin(big.NewInt(1)).SetMax(new(big.Int).Lsh(big.NewInt(1), 64))
+
+	testSchemaMarshal(t, schema, `{"$version":"1.1","$body":{"$type":"bigInt","cast":true,"min":"1","max":"18446744073709551616"}}`)
+	if _, _, err := pongo.UnmarshalPongoSchema([]byte(`{"$version": "1.1", "$body": {"$type": "bigInt", "$body": {"max": 1.5}}}`)); err == nil {
+		t.Errorf("expected an error on unmarshal of a non-integer max")
+	}
+
+	testSchemaJSONSchema(t, schema, map[pongo.SchemaAction]string{
+		pongo.SchemaActionParse:     `{"oneOf":[{"maximum":18446744073709551616,"minimum":1,"type":"integer"},{"pattern":"^[+-]?[0-9]+$","type":"string"}]}`,
+		pongo.SchemaActionSerialize: `{"pattern":"^-?[0-9]+$","type":"string"}`,
+	})
+	testSchemaLint(t, pongo.BigInt().SetMin(big.NewInt(2)).SetMax(big.NewInt(1)), "contradictory-range")
+}
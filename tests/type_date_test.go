@@ -0,0 +1,248 @@
+package tests
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+var testTypeDateCases = []testSchemaCase{
+	{
+		desc:   "type-date-ok-1",
+		schema: pongo.Date(),
+		data:   func() pongo.Data { return pongo.NewCivilDate(1990, time.May, 17) },
+		want:   func() pongo.Data { return pongo.NewCivilDate(1990, time.May, 17) },
+	},
+	{
+		desc:   "type-date-ok-2",
+		schema: pongo.Date().SetCast(true),
+		data:   func() pongo.Data { return "2024-02-29" },
+		want:   func() pongo.Data { return pongo.NewCivilDate(2024, time.February, 29) },
+	},
+	{
+		desc:   "type-date-ok-3",
+		schema: pongo.Date().SetCast(true),
+		data: func() pongo.Data {
+			return time.Date(1990, time.May, 17, 23, 30, 0, 0, time.FixedZone("UTC-5", -5*60*60))
+		},
+		want: func() pongo.Data { return pongo.NewCivilDate(1990, time.May, 17) },
+	},
+	{
+		desc:   "type-date-ok-4",
+		schema: pongo.Date().SetMin(pongo.NewCivilDate(2024, time.January, 1)).SetMax(pongo.NewCivilDate(2024, time.December, 31)),
+		data:   func() pongo.Data { return pongo.NewCivilDate(2024, time.December, 31) },
+		want:   func() pongo.Data { return pongo.NewCivilDate(2024, time.December, 31) },
+	},
+	{
+		desc:   "type-date-ko-1",
+		schema: pongo.Date(),
+		data:   func() pongo.Data { return "1990-05-17" },
+		errors: 1,
+	},
+	{
+		desc:   "type-date-ko-2",
+		schema: pongo.Date().SetCast(true),
+		data:   func() pongo.Data { return "2023-02-29" },
+		errors: 1,
+	},
+	{
+		desc:   "type-date-ko-3",
+		schema: pongo.Date(),
+		data:   func() pongo.Data { return pongo.NewCivilDate(2023, time.February, 29) },
+		errors: 1,
+	},
+	{
+		desc:   "type-date-ko-4",
+		schema: pongo.Date().SetMin(pongo.NewCivilDate(2024, time.January, 1)).SetMax(pongo.NewCivilDate(2024, time.December, 31)),
+		data:   func() pongo.Data { return pongo.NewCivilDate(2023, time.December, 31) },
+		errors: 1,
+	},
+}
+
+var testTypeDateSerializeCases = []testSchemaCase{
+	{
+		desc:   "type-date-serialize-ok-1",
+		schema: pongo.Date(),
+		data:   func() pongo.Data { return pongo.NewCivilDate(812, time.May, 7) },
+		want:   func() pongo.Data { return "0812-05-07" },
+	},
+	{
+		desc:   "type-date-serialize-ok-2",
+		schema: pongo.Date().SetCast(true),
+		data: func() pongo.Data {
+			return time.Date(1990, time.May, 17, 23, 30, 0, 0, time.FixedZone("UTC-5", -5*60*60))
+		},
+		want: func() pongo.Data { return "1990-05-17" },
+	},
+	{
+		desc:   "type-date-serialize-ko-1",
+		schema: pongo.Date(),
+		data:   func() pongo.Data { return time.Date(1990, time.May, 17, 0, 0, 0, 0, time.UTC) },
+		errors: 1,
+	},
+}
+
+var testTypeTimeOfDayCases = []testSchemaCase{
+	{
+		desc:   "type-time-of-day-ok-1",
+		schema: pongo.TimeOfDay(),
+		data:   func() pongo.Data { return pongo.NewCivilTime(9, 0, 0, 0) },
+		want:   func() pongo.Data { return pongo.NewCivilTime(9, 0, 0, 0) },
+	},
+	{
+		desc:   "type-time-of-day-ok-2",
+		schema: pongo.TimeOfDay().SetCast(true),
+		data:   func() pongo.Data { return "09:30" },
+		want:   func() pongo.Data { return pongo.NewCivilTime(9, 30, 0, 0) },
+	},
+	{
+		desc:   "type-time-of-day-ok-3",
+		schema: pongo.TimeOfDay().SetCast(true),
+		data:   func() pongo.Data { return "17:45:30.25" },
+		want:   func() pongo.Data { return pongo.NewCivilTime(17, 45, 30, 250000000) },
+	},
+	{
+		desc:   "type-time-of-day-ok-4",
+		schema: pongo.TimeOfDay().SetCast(true).SetMin(pongo.NewCivilTime(9, 0, 0, 0)).SetMax(pongo.NewCivilTime(18, 0, 0, 0)),
+		data:   func() pongo.Data { return "18:00:00" },
+		want:   func() pongo.Data { return pongo.NewCivilTime(18, 0, 0, 0) },
+	},
+	{
+		desc:   "type-time-of-day-ko-1",
+		schema: pongo.TimeOfDay().SetCast(true),
+		data:   func() pongo.Data { return "24:00" },
+		errors: 1,
+	},
+	{
+		desc:   "type-time-of-day-ko-2",
+		schema: pongo.TimeOfDay().SetCast(true),
+		data:   func() pongo.Data { return "09:00:00Z" },
+		errors: 1,
+	},
+	{
+		desc:   "type-time-of-day-ko-3",
+		schema: pongo.TimeOfDay().SetCast(true).SetMin(pongo.NewCivilTime(9, 0, 0, 0)).SetMax(pongo.NewCivilTime(18, 0, 0, 0)),
+		data:   func() pongo.Data { return "18:00:00.5" },
+		errors: 1,
+	},
+	{
+		desc:   "type-time-of-day-ko-4",
+		schema: pongo.TimeOfDay(),
+		data:   func() pongo.Data { return pongo.NewCivilTime(9, 60, 0, 0) },
+		errors: 1,
+	},
+}
+
+var testTypeTimeOfDaySerializeCases = []testSchemaCase{
+	{
+		desc:   "type-time-of-day-serialize-ok-1",
+		schema: pongo.TimeOfDay(),
+		data:   func() pongo.Data { return pongo.NewCivilTime(9, 5, 0, 0) },
+		want:   func() pongo.Data { return "09:05:00" },
+	},
+	{
+		desc:   "type-time-of-day-serialize-ok-2",
+		schema: pongo.TimeOfDay(),
+		data:   func() pongo.Data { return pongo.NewCivilTime(23, 59, 59, 120000000) },
+		want:   func() pongo.Data { return "23:59:59.12" },
+	},
+	{
+		desc:   "type-time-of-day-serialize-ok-3",
+		schema: pongo.TimeOfDay().SetCast(true),
+		data: func() pongo.Data {
+			return time.Date(2024, time.March, 31, 1, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+		},
+		want: func() pongo.Data { return "01:30:00" },
+	},
+}
+
+func TestTypeDate_Parse(t *testing.T) {
+	testSchemaCaseParse(testTypeDateCases)(t)
+}
+
+func TestTypeDate_Serialize(t *testing.T) {
+	testSchemaCaseSerialize(testTypeDateSerializeCases)(t)
+}
+
+func TestTypeTimeOfDay_Parse(t *testing.T) {
+	testSchemaCaseParse(testTypeTimeOfDayCases)(t)
+}
+
+func TestTypeTimeOfDay_Serialize(t *testing.T) {
+	testSchemaCaseSerialize(testTypeTimeOfDaySerializeCases)(t)
+}
+
+func TestTypeDateMarshal(t *testing.T) {
+	for _, c := range []struct {
+		schema     pongo.SchemaType
+		marshalled string
+		jsonSchema map[pongo.SchemaAction]string
+		invalid    pongo.SchemaType
+	}{
+		{
+			schema:     pongo.Date().SetCast(true).SetMin(pongo.NewCivilDate(1900, time.January, 1)).SetMax(pongo.NewCivilDate(2100, time.December, 31)),
+			marshalled: `{"$version":"1.1","$body":{"$type":"date","cast":true,"min":"1900-01-01","max":"2100-12-31"}}`,
+			jsonSchema: map[pongo.SchemaAction]string{
+				pongo.SchemaActionParse:     `{"format":"date","type":"string"}`,
+				pongo.SchemaActionSerialize: `{"format":"date","type":"string"}`,
+			},
+			invalid: pongo.Date().SetMin(pongo.NewCivilDate(2100, time.January, 1)).SetMax(pongo.NewCivilDate(1900, time.January, 1)),
+		},
+		{
+			schema:     pongo.TimeOfDay().SetCast(true).SetMin(pongo.NewCivilTime(9, 0, 0, 0)).SetMax(pongo.NewCivilTime(17, 30, 0, 0)),
+			marshalled: `{"$version":"1.1","$body":{"$type":"timeOfDay","cast":true,"min":"09:00:00","max":"17:30:00"}}`,
+			jsonSchema: map[pongo.SchemaAction]string{
+				pongo.SchemaActionParse:     `{"pattern":"^([01]?[0-9]|2[0-3]):[0-5][0-9](:[0-5][0-9]([.,][0-9]+)?)?$","type":"string"}`,
+				pongo.SchemaActionSerialize: `{"pattern":"^([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9](\\.[0-9]{1,9})?$","type":"string"}`,
+			},
+			invalid: pongo.TimeOfDay().SetMin(pongo.NewCivilTime(18, 0, 0, 0)).SetMax(pongo.NewCivilTime(9, 0, 0, 0)),
+		},
+	} {
+		testSchemaMarshalEqual(t, c.schema, c.marshalled)
+		testSchemaJSONSchema(t, c.schema, c.jsonSchema)
+		testSchemaLint(t, c.invalid, "contradictory-range")
+	}
+
+	if _, _, err := pongo.UnmarshalPongoSchema([]byte(`{"$version": "1.1", "$body": {"$type": "date", "min": "2023-02-29"}}`)); err == nil {
+		t.Errorf("expected an error on unmarshal of an invalid min date")
+	}
+}
+
+func TestTypeTimeOfDayJSONSchemaPattern(t *testing.T) {
+	pattern := func(schema pongo.SchemaType, action pongo.SchemaAction) *regexp.Regexp {
+		jsonSchema, err := pongo.MarshalJSONSchema(pongo.Schema(schema), action)
+		if err != nil {
+			t.Fatalf("unexpected error on JSON Schema marshal: %s", err)
+		}
+		var decoded struct{ Pattern string }
+		if err = json.Unmarshal(jsonSchema, &decoded); err != nil {
+			t.Fatalf("unexpected error on JSON Schema unmarshal: %s", err)
+		}
+		return regexp.MustCompile(decoded.Pattern)
+	}
+
+	schema := pongo.TimeOfDay().SetCast(true)
+	parsePattern := pattern(schema, pongo.SchemaActionParse)
+	serializePattern := pattern(schema, pongo.SchemaActionSerialize)
+	for _, s := range []string{"09:30", "9:30", "09:30:00", "23:59:59.5", "09:30:00,25", "09:30:00.1234567891", "24:00", "09:60", "09:30:5", "09:30:00.", "09:30:00Z", "09:30Z"} {
+		_, err := pongo.Parse(schema, s)
+		if matched := parsePattern.MatchString(s); matched != (err == nil) {
+			t.Errorf("expected the parse pattern match of %q to be %t, got %t", s, err == nil, matched)
+		}
+		if err != nil {
+			continue
+		}
+
+		serialized, err := pongo.Serialize(schema, s)
+		if err != nil {
+			t.Errorf("unexpected error on serialize of %q: %s", s, err)
+			continue
+		}
+		if !serializePattern.MatchString(serialized.(string)) {
+			t.Errorf("expected the serialize pattern to match %q", serialized)
+		}
+	}
+}
@@ -1,12 +1,15 @@
 package tests
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/kael-k/pongo/v2/pongo"
 )
 
+var testDatetimeLocation = time.FixedZone("UTC+2", 2*60*60)
+
 var testTypeDatetimeCases = []testSchemaCase{
 	{
 		desc:   "type-datatime-ok-1",
@@ -78,6 +81,60 @@ var testTypeDatetimeCases = []testSchemaCase{
 		want:   func() pongo.Data { return time.Unix(1663900000, 0) },
 		errors: 1,
 	},
+	{
+		desc:   "type-datatime-ok-8",
+		schema: pongo.Datetime().SetCast(true).SetLayouts("2006-01-02", time.RFC1123),
+		data:   func() pongo.Data { return "Fri, 09 Aug 2024 10:00:00 UTC" },
+		want:   func() pongo.Data { return time.Date(2024, time.August, 9, 10, 0, 0, 0, time.UTC) },
+	},
+	{
+		desc:   "type-datatime-ok-9",
+		schema: pongo.Datetime().SetCast(true).SetLayouts("2006-01-02", time.RFC1123),
+		data:   func() pongo.Data { return "2024-08-09" },
+		want:   func() pongo.Data { return time.Date(2024, time.August, 9, 0, 0, 0, 0, time.UTC) },
+	},
+	{
+		desc:   "type-datatime-ok-10",
+		schema: pongo.Datetime().SetCast(true).SetEpochUnit(pongo.EpochUnitMillis),
+		data:   func() pongo.Data { return int64(1660003200123) },
+		want:   func() pongo.Data { return time.Unix(1660003200, 123000000) },
+	},
+	{
+		desc:   "type-datatime-ok-11",
+		schema: pongo.Datetime().SetCast(true),
+		data:   func() pongo.Data { return 1660003200.5 },
+		want:   func() pongo.Data { return time.Unix(1660003200, 500000000) },
+	},
+	{
+		desc:   "type-datatime-ok-12",
+		schema: pongo.Datetime().SetCast(true).SetEpochUnit(pongo.EpochUnitNanos),
+		data:   func() pongo.Data { return -1 },
+		want:   func() pongo.Data { return time.Unix(0, -1) },
+	},
+	{
+		desc:   "type-datatime-ok-13",
+		schema: pongo.Datetime().SetCast(true).SetFormat("2006-01-02 15:04:05").SetLocation(testDatetimeLocation),
+		data:   func() pongo.Data { return "2024-08-09 10:00:00" },
+		want:   func() pongo.Data { return time.Date(2024, time.August, 9, 10, 0, 0, 0, testDatetimeLocation) },
+	},
+	{
+		desc:   "type-datatime-ok-14",
+		schema: pongo.Datetime().SetLocation(time.UTC),
+		data:   func() pongo.Data { return time.Date(2024, time.August, 9, 10, 0, 0, 0, testDatetimeLocation) },
+		want:   func() pongo.Data { return time.Date(2024, time.August, 9, 8, 0, 0, 0, time.UTC) },
+	},
+	{
+		desc:   "type-datatime-ko-5",
+		schema: pongo.Datetime().SetCast(true).SetLayouts("2006-01-02"),
+		data:   func() pongo.Data { return "09/08/2024" },
+		errors: 1,
+	},
+	{
+		desc:   "type-datatime-ko-6",
+		schema: pongo.Datetime().SetCast(true).SetEpochUnit("minutes"),
+		data:   func() pongo.Data { return 1 },
+		errors: 1,
+	},
 }
 
 var testDatetimeTypeSerializeCases = []testSchemaCase{
@@ -102,6 +159,24 @@ var testDatetimeTypeSerializeCases = []testSchemaCase{
 		want:   func() pongo.Data { return "not-a-datetime" },
 		errors: 1,
 	},
+	{
+		desc:   "datetime-serialize-ok-3",
+		schema: pongo.Datetime().SetFormatWithAction(pongo.SchemaActionSerialize, time.RFC1123),
+		data:   func() pongo.Data { return time.Unix(1660003200, 0).UTC() },
+		want:   func() pongo.Data { return "Tue, 09 Aug 2022 00:00:00 UTC" },
+	},
+	{
+		desc:   "datetime-serialize-ok-4",
+		schema: pongo.Datetime().SetLocation(testDatetimeLocation),
+		data:   func() pongo.Data { return time.Unix(1660003200, 0).UTC() },
+		want:   func() pongo.Data { return "2022-08-09T02:00:00+02:00" },
+	},
+	{
+		desc:   "datetime-serialize-ok-5",
+		schema: pongo.Datetime().SetCast(true).SetFormat("2006-01-02 15:04:05").SetLocation(time.UTC),
+		data:   func() pongo.Data { return time.Date(2022, time.August, 9, 2, 0, 0, 0, testDatetimeLocation) },
+		want:   func() pongo.Data { return "2022-08-09 00:00:00" },
+	},
 }
 
 func TestTypeDatetime_Parse(t *testing.T) {
@@ -111,3 +186,66 @@ func TestTypeDatetime_Parse(t *testing.T) {
 func TestTypeDatetime_Serialize(t *testing.T) {
 	testSchemaCaseSerialize(testDatetimeTypeSerializeCases)(t)
 }
+
+func TestTypeDatetimeMarshal(t *testing.T) {
+	schema := pongo.Datetime().SetCast(true).SetLayouts("2006-01-02").SetEpochUnit(pongo.EpochUnitMillis).SetLocation(time.UTC)
+
+	testSchemaMarshalEqual(t, schema, `{"$version":"1.1","$body":{"$type":"datetime","cast":true,"layouts":["2006-01-02"],"epochUnit":"millis","location":"UTC"}}`)
+
+	for _, body := range []string{`{"epochUnit": "minutes"}`, `{"location": "Nowhere/Atlantis"}`} {
+		if _, _, err := pongo.UnmarshalPongoSchema([]byte(`{"$version": "1.1", "$body": {"$type": "datetime", "$body": ` + body + `}}`)); err == nil {
+			t.Errorf("expected an error on unmarshal of %s", body)
+		}
+	}
+
+	testSchemaJSONSchema(t, pongo.Datetime().SetCast(true), map[pongo.SchemaAction]string{pongo.SchemaActionParse: `{"format":"date-time","type":"string"}`})
+	testSchemaJSONSchema(t, schema, map[pongo.SchemaAction]string{
+		pongo.SchemaActionParse:     `{"type":"string"}`,
+		pongo.SchemaActionSerialize: `{"format":"date-time","type":"string"}`,
+	})
+	testSchemaJSONSchema(t, pongo.Datetime().SetFormatWithAction(pongo.SchemaActionSerialize, time.RFC1123), map[pongo.SchemaAction]string{pongo.SchemaActionSerialize: `{"type":"string"}`})
+	testSchemaLint(t, pongo.Datetime().SetEpochUnit("minutes"), "invalid-epoch-unit")
+}
+
+func TestTypeDatetimeRelative(t *testing.T) {
+	now := time.Date(2024, time.August, 9, 12, 0, 0, 0, time.UTC)
+	options := pongo.ProcessOptions{Clock: func() time.Time { return now }}
+
+	for _, c := range []struct {
+		desc   string
+		schema *pongo.DatetimeType
+		data   time.Time
+		error  string
+	}{
+		{desc: "not-in-the-future-ok", schema: pongo.Datetime().SetBeforeRelative("PT0S"), data: now},
+		{
+			desc:   "not-in-the-future-ko",
+			schema: pongo.Datetime().SetBeforeRelative("PT0S"),
+			data:   now.Add(time.Second),
+			error:  "schema does not validate: .<datetime> value is 2024-08-09T12:00:01Z (Max: 2024-08-09T12:00:00Z, PT0S from now)",
+		},
+		{desc: "last-90-days-ok", schema: pongo.Datetime().SetAfterRelative("-P90D"), data: now.AddDate(0, 0, -90)},
+		{
+			desc:   "last-90-days-ko",
+			schema: pongo.Datetime().SetAfterRelative("-P90D"),
+			data:   now.AddDate(0, 0, -91),
+			error:  "schema does not validate: .<datetime> value is 2024-05-10T12:00:00Z (Min: 2024-05-11T12:00:00Z, -P90D from now)",
+		},
+		{desc: "at-least-18-years-ago-ok", schema: pongo.Datetime().SetBeforeRelative("-P18Y"), data: time.Date(2006, time.August, 9, 0, 0, 0, 0, time.UTC)},
+		{desc: "at-least-18-years-ago-ko", schema: pongo.Datetime().SetBeforeRelative("-P18Y"), data: time.Date(2006, time.August, 10, 0, 0, 0, 0, time.UTC), error: "-P18Y from now"},
+		{desc: "invalid-relative-ko", schema: pongo.Datetime().SetBeforeRelative("yesterday"), data: now, error: "invalid ISO 8601 duration"},
+	} {
+		_, err := pongo.ProcessWithOptions(c.schema, pongo.SchemaActionParse, c.data, options)
+		if c.error == "" && err != nil {
+			t.Errorf("%s: unexpected error %s", c.desc, err)
+		} else if c.error != "" && (err == nil || !strings.Contains(err.Error(), c.error)) {
+			t.Errorf("%s: expected error %q, got %v", c.desc, c.error, err)
+		}
+	}
+
+	schema := pongo.Datetime().SetCast(true).SetAfterRelative("-P90D").SetBeforeRelative("PT0S")
+	testSchemaMarshalEqual(t, schema, `{"$version":"1.1","$body":{"$type":"datetime","cast":true,"before":{"relative":"PT0S"},"after":{"relative":"-P90D"}}}`)
+
+	testSchemaLint(t, pongo.Datetime().SetAfterRelative("P1D").SetBeforeRelative("PT0S"), "contradictory-range")
+	testSchemaLint(t, pongo.Datetime().SetAfterRelative("yesterday"), "invalid-relative-bound")
+}
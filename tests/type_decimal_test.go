@@ -0,0 +1,200 @@
+package tests
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+func testDecimal() *pongo.DecimalType {
+	return pongo.Decimal().SetOutput(pongo.NumberOutputString)
+}
+
+var testTypeDecimalCases = []testSchemaCase{
+	{
+		desc:   "type-decimal-ok-1",
+		schema: testDecimal(),
+		data:   func() pongo.Data { return json.Number("12345678901234567890.123456789") },
+		want:   func() pongo.Data { return "12345678901234567890.123456789" },
+	},
+	{
+		desc:   "type-decimal-ok-2",
+		schema: testDecimal(),
+		data:   func() pongo.Data { return json.Number("1.5e3") },
+		want:   func() pongo.Data { return "1500" },
+	},
+	{
+		desc:   "type-decimal-ok-3",
+		schema: testDecimal().SetCast(true),
+		data:   func() pongo.Data { return 0.1 },
+		want:   func() pongo.Data { return "0.1" },
+	},
+	{
+		desc:   "type-decimal-ok-4",
+		schema: testDecimal().SetScale(2),
+		data:   func() pongo.Data { return json.Number("10.5") },
+		want:   func() pongo.Data { return "10.50" },
+	},
+	{
+		desc:   "type-decimal-ok-5",
+		schema: testDecimal().SetScale(2).SetRounding(pongo.RoundingHalfUp),
+		data:   func() pongo.Data { return json.Number("-2.345") },
+		want:   func() pongo.Data { return "-2.35" },
+	},
+	{
+		desc:   "type-decimal-ok-6",
+		schema: testDecimal().SetScale(2).SetRounding(pongo.RoundingHalfEven),
+		data:   func() pongo.Data { return json.Number("2.345") },
+		want:   func() pongo.Data { return "2.34" },
+	},
+	{
+		desc:   "type-decimal-ok-7",
+		schema: testDecimal().SetScale(0).SetRounding(pongo.RoundingFloor),
+		data:   func() pongo.Data { return json.Number("-2.1") },
+		want:   func() pongo.Data { return "-3" },
+	},
+	{
+		desc:   "type-decimal-ok-8",
+		schema: testDecimal().SetScale(1).SetRounding(pongo.RoundingCeiling),
+		data:   func() pongo.Data { return big.NewRat(1, 3) },
+		want:   func() pongo.Data { return "0.4" },
+	},
+	{
+		desc:   "type-decimal-ok-9",
+		schema: testDecimal().SetPrecision(5).SetScale(2).SetMin(big.NewRat(0, 1)).SetMax(big.NewRat(99999, 100)),
+		data:   func() pongo.Data { return json.Number("999.99") },
+		want:   func() pongo.Data { return "999.99" },
+	},
+	{
+		desc:   "type-decimal-ok-10",
+		schema: pongo.Decimal().SetOutput(pongo.NumberOutputNumber).SetCast(true),
+		data:   func() pongo.Data { return "-0.50" },
+		want:   func() pongo.Data { return json.Number("-0.5") },
+	},
+	{
+		desc:   "type-decimal-ko-1",
+		schema: testDecimal(),
+		data:   func() pongo.Data { return "1.5" },
+		errors: 1,
+	},
+	{
+		desc:   "type-decimal-ko-2",
+		schema: testDecimal().SetCast(true),
+		data:   func() pongo.Data { return "1/3" },
+		errors: 1,
+	},
+	{
+		desc:   "type-decimal-ko-3",
+		schema: testDecimal().SetScale(2),
+		data:   func() pongo.Data { return json.Number("2.345") },
+		errors: 1,
+	},
+	{
+		desc:   "type-decimal-ko-4",
+		schema: testDecimal().SetPrecision(5).SetScale(2),
+		data:   func() pongo.Data { return json.Number("1000") },
+		errors: 1,
+	},
+	{
+		desc:   "type-decimal-ko-5",
+		schema: testDecimal().SetMin(big.NewRat(0, 1)),
+		data:   func() pongo.Data { return json.Number("-0.01") },
+		errors: 1,
+	},
+	{
+		desc:   "type-decimal-ko-6",
+		schema: testDecimal(),
+		data:   func() pongo.Data { return json.Number("1e999999999") },
+		errors: 1,
+	},
+	{
+		desc:   "type-decimal-ko-7",
+		schema: testDecimal(),
+		data:   func() pongo.Data { return big.NewRat(1, 3) },
+		errors: 1,
+	},
+	{
+		desc:   "type-decimal-ko-8",
+		schema: testDecimal(),
+		data:   func() pongo.Data { return (*big.Rat)(nil) },
+		errors: 1,
+	},
+	{
+		desc:   "type-decimal-ko-9",
+		schema: testDecimal().SetCast(true),
+		data:   func() pongo.Data { return (*big.Int)(nil) },
+		errors: 1,
+	},
+}
+
+func TestTypeDecimal_Parse(t *testing.T) {
+	testSchemaCaseParse(testTypeDecimalCases)(t)
+}
+
+func TestTypeDecimalOutput(t *testing.T) {
+	schema := pongo.Decimal().SetScale(2).SetRounding(pongo.RoundingDown)
+
+	parsed, err := pongo.Parse(schema, json.Number("19.999"))
+	if r, ok := parsed.(*big.Rat); err != nil || !ok || r.Cmp(big.NewRat(1999, 100)) != 0 {
+		t.Errorf("expected the *big.Rat 19.99, got %#v, %v", parsed, err)
+	}
+	serialized, err := pongo.Serialize(schema, big.NewRat(1999, 100))
+	if err != nil || serialized != "19.99" {
+		t.Errorf("expected the string 19.99, got %#v, %v", serialized, err)
+	}
+
+	serialized, err = pongo.Serialize(schema.SetOutputWithAction(pongo.SchemaActionSerialize, pongo.NumberOutputNumber), big.NewRat(-1, 2))
+	if err != nil || serialized != json.Number("-0.50") {
+		t.Errorf("expected the json.Number -0.50, got %#v, %v", serialized, err)
+	}
+	b, _ := json.Marshal(serialized)
+	if string(b) != "-0.50" {
+		t.Errorf("expected the json.Number to be marshalled as a number, got %s", b)
+	}
+}
+
+func TestTypeDecimalNil(t *testing.T) {
+	schema := pongo.Object(pongo.O{"amount": pongo.Decimal()})
+	if _, err := pongo.Serialize(schema, map[string]interface{}{"amount": (*big.Rat)(nil)}); err == nil {
+		t.Errorf("expected an error on serialize of a nil *big.Rat")
+	}
+
+	decimal := pongo.Decimal().SetMin(nil).SetMax(big.NewRat(1, 1)).SetMax(nil)
+	if _, ok := decimal.Min.Get(); ok {
+		t.Errorf("expected SetMin(nil) to leave Min unset")
+	}
+	if _, ok := decimal.Max.Get(); ok {
+		t.Errorf("expected SetMax(nil) to unset Max")
+	}
+}
+
+func TestTypeDecimalMarshal(t *testing.T) {
+	schema := pongo.Decimal().SetCast(true).SetPrecision(10).SetScale(2).SetRounding(pongo.RoundingHalfEven).
+		SetMin(big.NewRat(-1, 2)).SetMax(big.NewRat(1000000, 1)).SetOutputWithAction(pongo.SchemaActionSerialize, pongo.NumberOutputNumber)
+
+	unmarshalled := testSchemaMarshal(t, schema, `{"$version":"1.1","$body":{"$type":"decimal","cast":true,"precision":10,"scale":2,"rounding":"halfEven","min":"-0.5","max":"1000000","output":{"actions":{"SERIALIZE":"number"}}}}`)
+	if unmarshalled != nil {
+		if _, ok := unmarshalled.Type().(*pongo.DecimalType); !ok {
+			t.Errorf("expected a *DecimalType, got %T", unmarshalled.Type())
+		}
+	}
+
+	for _, body := range []string{`{"rounding": "nearest"}`, `{"output": {"default": "float"}}`, `{"min": "1/3"}`} {
+		if _, _, err := pongo.UnmarshalPongoSchema([]byte(`{"$version": "1.1", "$body": {"$type": "decimal", "$body": ` + body + `}}`)); err == nil {
+			t.Errorf("expected an error on unmarshal of %s", body)
+		}
+	}
+
+	testSchemaJSONSchema(t, schema, map[pongo.SchemaAction]string{
+		pongo.SchemaActionParse:     `{"oneOf":[{"maximum":1000000,"minimum":-0.5,"type":"number"},{"pattern":"^[+-]?(?:[0-9]+(?:\\.[0-9]*)?|\\.[0-9]+)(?:[eE]([+-]?[0-9]+))?$","type":"string"}]}`,
+		pongo.SchemaActionSerialize: `{"maximum":1000000,"minimum":-0.5,"type":"number"}`,
+	})
+	if _, err := pongo.MarshalJSONSchema(pongo.Schema(pongo.Decimal().SetOutput(pongo.NumberOutputBig)), pongo.SchemaActionSerialize); err == nil {
+		t.Errorf("expected an error on JSON Schema marshal of a big output")
+	}
+
+	testSchemaLint(t, pongo.Decimal().SetPrecision(2).SetScale(3).SetMin(big.NewRat(1, 1)).SetMax(big.NewRat(0, 1)).SetRounding(pongo.RoundingUp), "contradictory-range", "invalid-precision")
+	testSchemaLint(t, pongo.Decimal().SetRounding(pongo.RoundingUp).SetOutput("float"), "useless-rounding", "invalid-number-output")
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+func testDiscriminatorSchema() pongo.SchemaType {
+	return pongo.Discriminator("kind", pongo.O{
+		"card": pongo.Object(pongo.O{
+			"kind":   pongo.String(),
+			"number": pongo.String().SetCast(true),
+		}).Require("number"),
+		"iban": pongo.Object(pongo.O{
+			"kind": pongo.String(),
+			"iban": pongo.String(),
+		}).Require("iban"),
+	})
+}
+
+var testTypeDiscriminatorCases = []testSchemaCase{
+	{
+		desc:   "type-discriminator-ok-1",
+		schema: testDiscriminatorSchema(),
+		data:   func() pongo.Data { return map[string]interface{}{"kind": "card", "number": 4111111111111111} },
+		want:   func() pongo.Data { return map[string]interface{}{"kind": "card", "number": "4111111111111111"} },
+	},
+	{
+		desc:   "type-discriminator-ok-2",
+		schema: testDiscriminatorSchema(),
+		data: func() pongo.Data {
+			return map[string]interface{}{"kind": "iban", "iban": "IT60X0542811101000000123456"}
+		},
+		want: func() pongo.Data {
+			return map[string]interface{}{"kind": "iban", "iban": "IT60X0542811101000000123456"}
+		},
+	},
+	{
+		desc:   "type-discriminator-ko-1",
+		schema: testDiscriminatorSchema(),
+		data:   func() pongo.Data { return map[string]interface{}{"kind": "iban", "number": "4111111111111111"} },
+		// the missing iban and the unknown number
+		errors: 2,
+	},
+	{
+		desc:   "type-discriminator-ko-2",
+		schema: testDiscriminatorSchema(),
+		data:   func() pongo.Data { return map[string]interface{}{"kind": "cash"} },
+		errors: 1,
+	},
+	{
+		desc:   "type-discriminator-ko-3",
+		schema: testDiscriminatorSchema(),
+		data:   func() pongo.Data { return map[string]interface{}{"kind": 1} },
+		errors: 1,
+	},
+	{
+		desc:   "type-discriminator-ko-4",
+		schema: testDiscriminatorSchema(),
+		data:   func() pongo.Data { return map[string]interface{}{"iban": "IT60X0542811101000000123456"} },
+		errors: 1,
+	},
+	{
+		desc:   "type-discriminator-ko-5",
+		schema: testDiscriminatorSchema(),
+		data:   func() pongo.Data { return "card" },
+		errors: 1,
+	},
+}
+
+func TestTypeDiscriminator_Parse(t *testing.T) {
+	testSchemaCaseParse(testTypeDiscriminatorCases)(t)
+}
+
+func TestTypeDiscriminatorLint(t *testing.T) {
+	findings := pongo.Lint(pongo.Discriminator("", nil))
+	if len(findings) != 2 || findings[0].Rule != "empty-discriminator" || findings[1].Rule != "empty-combinator" {
+		t.Errorf("unexpected lint findings %v", findings)
+	}
+
+	findings = pongo.Lint(pongo.Discriminator("kind", pongo.O{"card": pongo.String().SetMinLen(3).SetMaxLen(1)}))
+	if len(findings) != 1 || findings[0].Path != ".<discriminator>.card<string>" {
+		t.Errorf("unexpected lint findings %v", findings)
+	}
+}
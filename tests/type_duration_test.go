@@ -0,0 +1,186 @@
+package tests
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+var testTypeDurationCases = []testSchemaCase{
+	{
+		desc:   "type-duration-ok-1",
+		schema: pongo.Duration(),
+		data:   func() pongo.Data { return 90 * time.Minute },
+		want:   func() pongo.Data { return 90 * time.Minute },
+	},
+	{
+		desc:   "type-duration-ok-2",
+		schema: pongo.Duration().SetCast(true),
+		data:   func() pongo.Data { return "1h30m" },
+		want:   func() pongo.Data { return 90 * time.Minute },
+	},
+	{
+		desc:   "type-duration-ok-3",
+		schema: pongo.Duration().SetCast(true),
+		data:   func() pongo.Data { return "PT15M" },
+		want:   func() pongo.Data { return 15 * time.Minute },
+	},
+	{
+		desc:   "type-duration-ok-4",
+		schema: pongo.Duration().SetCast(true),
+		data:   func() pongo.Data { return "P1W1DT0.5S" },
+		want:   func() pongo.Data { return 8*24*time.Hour + 500*time.Millisecond },
+	},
+	{
+		desc:   "type-duration-ok-5",
+		schema: pongo.Duration().SetCast(true),
+		data:   func() pongo.Data { return 1.5 },
+		want:   func() pongo.Data { return 1500 * time.Millisecond },
+	},
+	{
+		desc:   "type-duration-ok-6",
+		schema: pongo.Duration().SetCast(true).SetMin(time.Minute).SetMax(time.Hour),
+		data:   func() pongo.Data { return 60 },
+		want:   func() pongo.Data { return time.Minute },
+	},
+	{
+		desc:   "type-duration-ok-7",
+		schema: pongo.Duration().SetCast(true),
+		data:   func() pongo.Data { return "-PT1M" },
+		want:   func() pongo.Data { return -time.Minute },
+	},
+	{
+		desc:   "type-duration-ko-1",
+		schema: pongo.Duration(),
+		data:   func() pongo.Data { return "1h" },
+		errors: 1,
+	},
+	{
+		desc:   "type-duration-ko-2",
+		schema: pongo.Duration().SetCast(true),
+		data:   func() pongo.Data { return "P1M" },
+		errors: 1,
+	},
+	{
+		desc:   "type-duration-ko-3",
+		schema: pongo.Duration().SetCast(true),
+		data:   func() pongo.Data { return "one hour" },
+		errors: 1,
+	},
+	{
+		desc:   "type-duration-ko-4",
+		schema: pongo.Duration().SetCast(true).SetMin(time.Minute).SetMax(time.Hour),
+		data:   func() pongo.Data { return "PT2H" },
+		errors: 1,
+	},
+	{
+		desc:   "type-duration-ko-5",
+		schema: pongo.Duration().SetCast(true),
+		data:   func() pongo.Data { return math.Inf(1) },
+		errors: 1,
+	},
+	{
+		desc:   "type-duration-ko-6",
+		schema: pongo.Duration().SetCast(true),
+		data:   func() pongo.Data { return 1e10 },
+		errors: 1,
+	},
+	{
+		desc:   "type-duration-ko-7",
+		schema: pongo.Duration().SetCast(true),
+		data:   func() pongo.Data { return int64(math.MaxInt64) },
+		errors: 1,
+	},
+	{
+		desc:   "type-duration-ko-8",
+		schema: pongo.Duration().SetCast(true),
+		data:   func() pongo.Data { return "P2635249153387078803W" },
+		errors: 1,
+	},
+	{
+		desc:   "type-duration-ko-9",
+		schema: pongo.Duration().SetCast(true),
+		data:   func() pongo.Data { return "PT2562047H60M" },
+		errors: 1,
+	},
+	{
+		desc:   "type-duration-ko-10",
+		schema: pongo.Duration().SetCast(true),
+		data:   func() pongo.Data { return "P106751DT23H59M" },
+		errors: 1,
+	},
+}
+
+var testTypeDurationSerializeCases = []testSchemaCase{
+	{
+		desc:   "type-duration-serialize-ok-1",
+		schema: pongo.Duration(),
+		data:   func() pongo.Data { return 90 * time.Minute },
+		want:   func() pongo.Data { return "1h30m0s" },
+	},
+	{
+		desc:   "type-duration-serialize-ok-2",
+		schema: pongo.Duration().SetFormat(pongo.DurationFormatISO),
+		data:   func() pongo.Data { return 26*time.Hour + 90*time.Second + 250*time.Millisecond },
+		want:   func() pongo.Data { return "PT26H1M30.25S" },
+	},
+	{
+		desc:   "type-duration-serialize-ok-3",
+		schema: pongo.Duration().SetFormat(pongo.DurationFormatISO),
+		data:   func() pongo.Data { return time.Duration(0) },
+		want:   func() pongo.Data { return "PT0S" },
+	},
+	{
+		desc:   "type-duration-serialize-ok-4",
+		schema: pongo.Duration().SetFormat(pongo.DurationFormatISO),
+		data:   func() pongo.Data { return -time.Second },
+		want:   func() pongo.Data { return "-PT1S" },
+	},
+	{
+		desc:   "type-duration-serialize-ok-5",
+		schema: pongo.Duration().SetFormat(pongo.DurationFormatSeconds),
+		data:   func() pongo.Data { return 1500 * time.Millisecond },
+		want:   func() pongo.Data { return 1.5 },
+	},
+	{
+		desc:   "type-duration-serialize-ko-1",
+		schema: pongo.Duration().SetFormat("weeks"),
+		data:   func() pongo.Data { return time.Second },
+		errors: 1,
+	},
+}
+
+func TestTypeDuration_Parse(t *testing.T) {
+	testSchemaCaseParse(testTypeDurationCases)(t)
+}
+
+func TestTypeDuration_Serialize(t *testing.T) {
+	testSchemaCaseSerialize(testTypeDurationSerializeCases)(t)
+}
+
+func TestTypeDurationMarshal(t *testing.T) {
+	schema := pongo.Duration().SetCast(true).SetMin(time.Minute).SetMax(36 * time.Hour).SetFormat(pongo.DurationFormatISO)
+
+	testSchemaMarshalEqual(t, schema, `{"$version":"1.1","$body":{"$type":"duration","cast":true,"min":"1m0s","max":"36h0m0s","format":"iso8601"}}`)
+
+	unmarshalled, _, err := pongo.UnmarshalPongoSchema([]byte(`{"$version": "1.1", "$body": {"$type": "duration", "max": "P1D"}}`))
+	if err != nil {
+		t.Errorf("unexpected error on unmarshal of an ISO 8601 max: %s", err)
+	} else if max, _ := unmarshalled.Type().(*pongo.DurationType).Max.Get(); max != 24*time.Hour {
+		t.Errorf("expected max 24h, got %s", max)
+	}
+
+	testSchemaJSONSchema(t, schema, map[pongo.SchemaAction]string{
+		pongo.SchemaActionParse:     `{"type":["string","number"]}`,
+		pongo.SchemaActionSerialize: `{"format":"duration","type":"string"}`,
+	})
+	testSchemaLint(t, pongo.Duration().SetMin(time.Hour).SetMax(time.Minute), "contradictory-range")
+}
+
+func TestTypeDurationNaN(t *testing.T) {
+	if _, err := pongo.Parse(pongo.Duration().SetCast(true), math.NaN()); err == nil {
+		t.Errorf("expected an error on parse of NaN seconds")
+	}
+}
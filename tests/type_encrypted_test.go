@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+var testEncryptedKeys = map[string][]byte{
+	"k1": bytes.Repeat([]byte{1}, 32),
+	"k2": bytes.Repeat([]byte{2}, 16),
+}
+
+func init() {
+	provider, err := pongo.NewAESGCMKeyProvider("k2", testEncryptedKeys)
+	if err != nil {
+		panic(err)
+	}
+	if err = pongo.RegisterKeyProvider("acme.io/test-pii", provider); err != nil {
+		panic(err)
+	}
+}
+
+func testEncryptedSchema() *pongo.ObjectType {
+	return pongo.OrderedObject(
+		pongo.Property("name", pongo.String()),
+		pongo.Property("ssn", pongo.Encrypted("acme.io/test-pii", pongo.String().SetMinLen(9))),
+		pongo.Property("age", pongo.Encrypted("acme.io/test-pii", pongo.Int().SetCastActions(pongo.SchemaActionParse))),
+	)
+}
+
+func TestEncryptedType(t *testing.T) {
+	data := map[string]interface{}{"name": "John", "ssn": "078051120", "age": 42}
+
+	serialized, err := pongo.Serialize(testEncryptedSchema(), data)
+	if err != nil {
+		t.Errorf("unexpected error on serialize: %s", err)
+		return
+	}
+	ssn, _ := serialized.(map[string]interface{})["ssn"].(string)
+	if !strings.HasPrefix(ssn, "k2:") || strings.Contains(ssn, "078051120") {
+		t.Errorf("expected the ssn to be encrypted with k2, got %s", ssn)
+	}
+
+	parsed, err := pongo.Parse(testEncryptedSchema(), serialized)
+	if err != nil {
+		t.Errorf("unexpected error on parse: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(parsed, data) {
+		t.Errorf("expected %v, got %v", data, parsed)
+	}
+}
+
+func TestEncryptedTypeKeyRotation(t *testing.T) {
+	old, err := pongo.NewAESGCMKeyProvider("k1", map[string][]byte{"k1": testEncryptedKeys["k1"]})
+	if err != nil {
+		t.Errorf("unexpected error on NewAESGCMKeyProvider: %s", err)
+		return
+	}
+	ciphertext, err := old.Encrypt([]byte(`"078051120"`))
+	if err != nil {
+		t.Errorf("unexpected error on Encrypt: %s", err)
+		return
+	}
+
+	schema := pongo.Encrypted("acme.io/test-pii", pongo.String())
+	if parsed, err := pongo.Parse(schema, ciphertext); err != nil || parsed != "078051120" {
+		t.Errorf("expected the data encrypted with the old key to be decrypted, got %v, %v", parsed, err)
+	}
+
+	for ciphertext, want := range map[string]error{
+		"k3:" + strings.SplitN(ciphertext, ":", 2)[1]: pongo.ErrKeyNotFound,
+		"k2:" + strings.SplitN(ciphertext, ":", 2)[1]: pongo.ErrInvalidCiphertext,
+		"no-key-id": pongo.ErrInvalidCiphertext,
+		"k1:AAAA":   pongo.ErrInvalidCiphertext,
+	} {
+		_, err = pongo.Parse(schema, ciphertext)
+		if schemaErr, ok := err.(*pongo.SchemaError); !ok || !errors.Is(schemaErr.Errors[0].Error(), want) {
+			t.Errorf("expected %s on parse of %s, got %v", want, ciphertext, err)
+		}
+	}
+
+	if _, err = pongo.NewAESGCMKeyProvider("k1", map[string][]byte{"k1": []byte("short")}); err == nil {
+		t.Errorf("expected an error with an invalid AES key")
+	}
+	if _, err = pongo.NewAESGCMKeyProvider("k:1", map[string][]byte{"k:1": testEncryptedKeys["k1"]}); err == nil {
+		t.Errorf("expected an error with a key ID containing \":\"")
+	}
+	if _, err = pongo.NewAESGCMKeyProvider("k3", testEncryptedKeys); !errors.Is(err, pongo.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound with an unknown current key, got %v", err)
+	}
+}
+
+func TestEncryptedTypeErrors(t *testing.T) {
+	serialized, err := pongo.Serialize(pongo.Encrypted("acme.io/test-pii", pongo.String()), "0780")
+	if err != nil {
+		t.Errorf("unexpected error on serialize: %s", err)
+		return
+	}
+
+	_, err = pongo.Parse(testEncryptedSchema(), map[string]interface{}{"ssn": serialized})
+	schemaErr, ok := err.(*pongo.SchemaError)
+	if !ok || len(schemaErr.Errors) != 1 {
+		t.Errorf("expected 1 error, got %v", err)
+		return
+	}
+	if strings.Contains(schemaErr.Error(), "0780") {
+		t.Errorf("expected the plaintext to be redacted, got %s", schemaErr)
+	}
+	if path := schemaErr.Errors[0].Path().String(); path != ".<object>.ssn<encrypted>.plaintext<string>" {
+		t.Errorf("unexpected error path %s", path)
+	}
+
+	_, err = pongo.Parse(pongo.Encrypted("acme.io/test-missing", pongo.String()), serialized)
+	if schemaErr, ok := err.(*pongo.SchemaError); !ok || !errors.Is(schemaErr.Errors[0].Error(), pongo.ErrKeyProviderNotRegistered) {
+		t.Errorf("expected ErrKeyProviderNotRegistered, got %v", err)
+	}
+
+	if err = pongo.RegisterKeyProvider("acme.io/test-pii", nil); !errors.Is(err, pongo.ErrKeyProviderAlreadyRegistered) {
+		t.Errorf("expected ErrKeyProviderAlreadyRegistered, got %v", err)
+	}
+}
+
+func TestEncryptedTypeMarshal(t *testing.T) {
+	testSchemaMarshalEqual(t, testEncryptedSchema(), `{"$version":"1.1","$body":{"$type":"object","properties":{"name":"string","ssn":{"$type":"encrypted","provider":"acme.io/test-pii","type":{"$type":"string","minLen":9}},"age":{"$type":"encrypted","provider":"acme.io/test-pii","type":{"$type":"int","cast":["PARSE"]}}}}}`)
+
+	testSchemaJSONSchema(t, pongo.Encrypted("acme.io/test-pii", pongo.Int()), map[pongo.SchemaAction]string{
+		pongo.SchemaActionParse: `{"type":"string"}`,
+	})
+
+	testSchemaLint(t, pongo.Encrypted("acme.io/test-missing", nil), "nil-encrypted-type", "unregistered-key-provider")
+}
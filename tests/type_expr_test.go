@@ -0,0 +1,235 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+func testExprPeriodSchema() pongo.SchemaType {
+	return pongo.AllOf(
+		pongo.Object(pongo.O{
+			"start": pongo.Datetime(),
+			"end":   pongo.Datetime(),
+		}),
+		pongo.Expr("@.end > @.start").SetCode("invalid-period").SetMessage("end must be after start"),
+	)
+}
+
+func testExprPromoSchema() pongo.SchemaType {
+	return pongo.Object(pongo.O{
+		"type":     pongo.String(),
+		"discount": pongo.Int(),
+		"items":    pongo.List(pongo.String()),
+		"code": pongo.AllOf(
+			pongo.String(),
+			pongo.Expr("^.type != 'promo' || ^.discount != null && ^.discount * 2 <= 100 && len(@) >= len(^.items[0])"),
+		),
+	})
+}
+
+var testTypeExprCases = []testSchemaCase{
+	{
+		desc:   "type-expr-ok-1",
+		schema: testExprPeriodSchema(),
+		data: func() pongo.Data {
+			return map[string]interface{}{"start": time.Unix(1660003200, 0), "end": time.Unix(1660003201, 0)}
+		},
+		want: func() pongo.Data {
+			return map[string]interface{}{"start": time.Unix(1660003200, 0), "end": time.Unix(1660003201, 0)}
+		},
+	},
+	{
+		desc:   "type-expr-ko-1",
+		schema: testExprPeriodSchema(),
+		data: func() pongo.Data {
+			return map[string]interface{}{"start": time.Unix(1660003200, 0), "end": time.Unix(1660003200, 0)}
+		},
+		errors: 1,
+	},
+	{
+		desc:   "type-expr-ok-2",
+		schema: testExprPromoSchema(),
+		data: func() pongo.Data {
+			return map[string]interface{}{"type": "promo", "discount": 10, "code": "abcd", "items": []interface{}{"abc"}}
+		},
+		want: func() pongo.Data {
+			return map[string]interface{}{"type": "promo", "discount": 10, "code": "abcd", "items": []interface{}{"abc"}}
+		},
+	},
+	{
+		desc:   "type-expr-ok-3",
+		schema: testExprPromoSchema(),
+		data:   func() pongo.Data { return map[string]interface{}{"type": "standard", "code": "a"} },
+		want:   func() pongo.Data { return map[string]interface{}{"type": "standard", "code": "a"} },
+	},
+	{
+		desc:   "type-expr-ko-2",
+		schema: testExprPromoSchema(),
+		data:   func() pongo.Data { return map[string]interface{}{"type": "promo", "code": "a"} },
+		errors: 1,
+	},
+	{
+		desc:   "type-expr-ko-3",
+		schema: testExprPromoSchema(),
+		data: func() pongo.Data {
+			return map[string]interface{}{"type": "promo", "discount": 51, "code": "abcd", "items": []interface{}{"abc"}}
+		},
+		errors: 1,
+	},
+	{
+		desc:   "type-expr-ok-4",
+		schema: pongo.Expr("-(1 + 2) * 3 == -9 && 7 % 4 == 3 && 'a' + \"b\" == 'ab' && !false && $ == @ && now() > '2020-01-01T00:00:00Z'"),
+		data:   func() pongo.Data { return 1 },
+		want:   func() pongo.Data { return 1 },
+	},
+	{
+		desc:   "type-expr-ko-4",
+		schema: pongo.Expr("@ + 1"),
+		data:   func() pongo.Data { return 1 },
+		errors: 1,
+	},
+	{
+		desc:   "type-expr-ko-5",
+		schema: pongo.Expr("@ > 'a'"),
+		data:   func() pongo.Data { return 1 },
+		errors: 1,
+	},
+	{
+		desc:   "type-expr-ko-6",
+		schema: pongo.Expr("@ / 0 == 1"),
+		data:   func() pongo.Data { return 1 },
+		errors: 1,
+	},
+	{
+		// the strings are RFC 3339 times, so they are compared taking into account their UTC offsets
+		desc:   "type-expr-ok-times-1",
+		schema: pongo.Expr("'2024-01-01T01:00:00+02:00' < '2024-01-01T00:00:00Z' && '2024-01-01T02:00:00+02:00' == '2024-01-01T00:00:00Z'"),
+		data:   func() pongo.Data { return nil },
+		want:   func() pongo.Data { return nil },
+	},
+	{
+		desc:   "type-expr-ok-times-2",
+		schema: pongo.Expr("@.start < @.end"),
+		data: func() pongo.Data {
+			return map[string]interface{}{"start": "2024-01-01T09:00:00+02:00", "end": "2024-01-01T08:00:00Z"}
+		},
+		want: func() pongo.Data {
+			return map[string]interface{}{"start": "2024-01-01T09:00:00+02:00", "end": "2024-01-01T08:00:00Z"}
+		},
+	},
+	{
+		desc:   "type-expr-ko-times-1",
+		schema: pongo.Expr("@.start < @.end"),
+		data: func() pongo.Data {
+			return map[string]interface{}{"start": "2024-01-01T09:00:00-02:00", "end": "2024-01-01T10:00:00Z"}
+		},
+		errors: 1,
+	},
+	{
+		// not RFC 3339 times, compared as text
+		desc:   "type-expr-ok-strings-1",
+		schema: pongo.Expr("'b' > 'a' && '2024-01-01' < '2024-01-02'"),
+		data:   func() pongo.Data { return nil },
+		want:   func() pongo.Data { return nil },
+	},
+}
+
+func TestTypeExpr_Parse(t *testing.T) {
+	testSchemaCaseParse(testTypeExprCases)(t)
+}
+
+func TestTypeExprError(t *testing.T) {
+	_, err := pongo.Parse(testExprPeriodSchema(), map[string]interface{}{"start": time.Unix(1, 0), "end": time.Unix(0, 0)})
+	schemaErr, ok := err.(*pongo.SchemaError)
+	if !ok || len(schemaErr.Errors) != 1 {
+		t.Errorf("expected a *SchemaError with 1 error, got %v", err)
+		return
+	}
+
+	var exprErr pongo.ExprError
+	if !errors.As(schemaErr.Errors[0].Error(), &exprErr) {
+		t.Errorf("expected an ExprError, got %v", schemaErr.Errors[0].Error())
+		return
+	}
+	if exprErr.Code != "invalid-period" || exprErr.Message != "end must be after start" {
+		t.Errorf("unexpected ExprError %v", exprErr)
+	}
+	if !errors.Is(schemaErr.Errors[0].Error(), pongo.ErrExprNotSatisfied) {
+		t.Errorf("expected ErrExprNotSatisfied")
+	}
+}
+
+func TestTypeExprSyntax(t *testing.T) {
+	for _, expression := range []string{"", "@ ==", "(@", "@.", "foo", "len()", "len(@, @)", "'abc", "@ # 1", "@[1"} {
+		findings := pongo.Lint(pongo.Expr(expression))
+		if len(findings) != 1 || findings[0].Rule != "invalid-expression" {
+			t.Errorf("expected an invalid-expression lint finding on %q, got %v", expression, findings)
+		}
+		if _, err := pongo.Parse(pongo.Expr(expression), 1); !errors.Is(err.(*pongo.SchemaError).Errors[0].Error(), pongo.ErrInvalidExpr) {
+			t.Errorf("expected ErrInvalidExpr on %q, got %v", expression, err)
+		}
+	}
+
+	_, _, err := pongo.UnmarshalPongoSchema([]byte(`{"$version":"1.1","$body":{"$type":"expr","expr":"@ =="}}`))
+	if !errors.Is(err, pongo.ErrInvalidExpr) {
+		t.Errorf("expected ErrInvalidExpr on unmarshal of an invalid expression, got %v", err)
+	}
+}
+
+func TestTypeExprUncompiled(t *testing.T) {
+	if _, err := pongo.Parse(&pongo.ExprType{Expr: "@ > 1"}, 2); err != nil {
+		t.Errorf("expected an ExprType struct literal to be compiled on process, got %s", err)
+	}
+	if _, err := pongo.Parse(&pongo.ExprType{Expr: "@ > 1"}, 1); err == nil {
+		t.Errorf("expected an ExprType struct literal not to validate")
+	}
+	if _, err := pongo.Parse(&pongo.ExprType{}, 1); !errors.Is(err.(*pongo.SchemaError).Errors[0].Error(), pongo.ErrInvalidExpr) {
+		t.Errorf("expected ErrInvalidExpr on an empty ExprType, got %v", err)
+	}
+	if findings := pongo.Lint(&pongo.ExprType{Expr: "@ =="}); len(findings) != 1 || findings[0].Rule != "invalid-expression" {
+		t.Errorf("expected an invalid-expression lint finding, got %v", findings)
+	}
+
+	schema := pongo.Expr("@ > 1")
+	schema.Expr = "@ > 10"
+	if _, err := pongo.Parse(schema, 2); err == nil {
+		t.Errorf("expected the changed expression to be evaluated")
+	}
+}
+
+func TestTypeExprMarshal(t *testing.T) {
+	schema := testExprPeriodSchema()
+	marshalled, err := pongo.MarshalPongoSchemaWithOptions(schema, nil, pongo.PongoSchemaMarshalOptions{Compact: true})
+	if err != nil {
+		t.Errorf("unexpected error on marshal: %s", err)
+		return
+	}
+
+	unmarshalled, _, err := pongo.UnmarshalPongoSchema(marshalled)
+	if err != nil {
+		t.Errorf("unexpected error on unmarshal: %s", err)
+		return
+	}
+	if _, err = pongo.Parse(unmarshalled, map[string]interface{}{"start": time.Unix(0, 0), "end": time.Unix(1, 0)}); err != nil {
+		t.Errorf("unexpected error on parse with the unmarshalled schema: %s", err)
+	}
+	_, err = pongo.Parse(unmarshalled, map[string]interface{}{"start": time.Unix(1, 0), "end": time.Unix(0, 0)})
+	if schemaErr, ok := err.(*pongo.SchemaError); !ok || !errors.Is(schemaErr.Errors[0].Error(), pongo.ErrExprNotSatisfied) {
+		t.Errorf("expected the unmarshalled expression to fail on an invalid period, got %v", err)
+	}
+}
+
+func TestTypeExprClock(t *testing.T) {
+	schema := pongo.Expr("now() < '2000-01-01T00:00:00Z'")
+	options := pongo.ProcessOptions{Clock: func() time.Time { return time.Date(1999, time.December, 31, 0, 0, 0, 0, time.UTC) }}
+
+	if _, err := pongo.ProcessWithOptions(schema, pongo.SchemaActionParse, nil, options); err != nil {
+		t.Errorf("expected now() to use the Clock of the options, got %s", err)
+	}
+	if _, err := pongo.Parse(schema, nil); err == nil {
+		t.Errorf("expected now() to be the current time without a Clock")
+	}
+}
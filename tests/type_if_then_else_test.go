@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+var testTypeIfThenElseCases = []testSchemaCase{
+	{
+		desc:   "type-if-then-else-ok-1",
+		schema: pongo.IfThenElse(pongo.Int(), pongo.Int().SetMin(10), pongo.String().SetCast(true)),
+		data:   func() pongo.Data { return 10 },
+		want:   func() pongo.Data { return 10 },
+	},
+	{
+		desc:   "type-if-then-else-ok-2",
+		schema: pongo.IfThenElse(pongo.Int(), pongo.Int().SetMin(10), pongo.String().SetCast(true)),
+		data:   func() pongo.Data { return 1.5 },
+		want:   func() pongo.Data { return "1.5" },
+	},
+	{
+		desc:   "type-if-then-else-ok-3",
+		schema: pongo.IfThenElse(pongo.String(), nil, pongo.Int()),
+		data:   func() pongo.Data { return "abc" },
+		want:   func() pongo.Data { return "abc" },
+	},
+	{
+		desc:   "type-if-then-else-ok-4",
+		schema: pongo.IfThenElse(pongo.String(), pongo.String().SetMinLen(2), nil),
+		data:   func() pongo.Data { return 1 },
+		want:   func() pongo.Data { return 1 },
+	},
+	{
+		desc:   "type-if-then-else-ko-1",
+		schema: pongo.IfThenElse(pongo.Int(), pongo.Int().SetMin(10), pongo.String().SetCast(true)),
+		data:   func() pongo.Data { return 9 },
+		errors: 1,
+	},
+	{
+		desc:   "type-if-then-else-ko-2",
+		schema: pongo.IfThenElse(pongo.String(), nil, pongo.Int()),
+		data:   func() pongo.Data { return true },
+		errors: 1,
+	},
+	{
+		desc:   "type-if-then-else-ko-3",
+		schema: pongo.IfThenElse(nil, pongo.Int(), nil),
+		data:   func() pongo.Data { return 1 },
+		errors: 1,
+	},
+}
+
+func TestTypeIfThenElse_Parse(t *testing.T) {
+	testSchemaCaseParse(testTypeIfThenElseCases)(t)
+}
+
+func TestTypeIfThenElseLint(t *testing.T) {
+	findings := pongo.Lint(pongo.IfThenElse(nil, pongo.Int(), nil))
+	if len(findings) != 1 || findings[0].Rule != "nil-if-type" {
+		t.Errorf("unexpected lint findings %v", findings)
+	}
+
+	findings = pongo.Lint(pongo.IfThenElse(pongo.Int(), nil, nil))
+	if len(findings) != 1 || findings[0].Rule != "useless-condition" {
+		t.Errorf("unexpected lint findings %v", findings)
+	}
+}
+
+func TestTypeIfThenElseJSONSchema(t *testing.T) {
+	testSchemaJSONSchema(t, pongo.IfThenElse(pongo.Int(), nil, pongo.String()), map[pongo.SchemaAction]string{
+		pongo.SchemaActionParse: `{"else":{"type":"string"},"if":{"type":"integer"}}`,
+	})
+	testSchemaJSONSchema(t, pongo.IfThenElse(nil, pongo.Int(), pongo.String()), map[pongo.SchemaAction]string{
+		pongo.SchemaActionParse:     `{"not":{}}`,
+		pongo.SchemaActionSerialize: `{"not":{}}`,
+	})
+
+	schema := pongo.IfThenElse(pongo.Expr("@ > 5"), pongo.Int(), pongo.String())
+	if _, err := pongo.MarshalJSONSchema(pongo.Schema(schema), pongo.SchemaActionParse); !errors.Is(err, pongo.ErrSchemaNotJSONSchemaMarshalable) {
+		t.Errorf("expected ErrSchemaNotJSONSchemaMarshalable on JSON Schema marshal of an if exporting no JSON Schema, got %v", err)
+	}
+}
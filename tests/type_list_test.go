@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/kael-k/pongo/v2/pongo"
@@ -101,3 +102,143 @@ func TestTypeList_Parse(t *testing.T) {
 func TestTypeList_Serialize(t *testing.T) {
 	testSchemaCaseProcess(testListTypeSerializeCases, pongo.SchemaActionSerialize)(t)
 }
+
+var testListTypeUniqueContainsCases = []testSchemaCase{
+	{
+		desc:   "list-unique-ok-1",
+		schema: pongo.List(pongo.Int()).SetUniqueItems(true),
+		data:   func() pongo.Data { return []interface{}{1, 2, 3} },
+		want:   func() pongo.Data { return []interface{}{1, 2, 3} },
+	},
+	{
+		desc:   "list-unique-ko-1",
+		schema: pongo.List(pongo.Int()).SetUniqueItems(true),
+		data:   func() pongo.Data { return []interface{}{1, 2, 1, 1} },
+		errors: 2,
+	},
+	{
+		desc:   "list-unique-ko-2",
+		schema: pongo.List(pongo.String().SetCast(true)).SetUniqueItems(true),
+		data:   func() pongo.Data { return []interface{}{1, "1"} },
+		errors: 1,
+	},
+	{
+		desc: "list-unique-by-ok-1",
+		schema: pongo.List(pongo.Object(pongo.O{
+			"id":   pongo.Int(),
+			"name": pongo.String(),
+		})).SetUniqueBy("id"),
+		data: func() pongo.Data {
+			return []interface{}{
+				map[string]interface{}{"id": 1, "name": "a"},
+				map[string]interface{}{"id": 2, "name": "a"},
+			}
+		},
+		want: func() pongo.Data {
+			return []interface{}{
+				map[string]interface{}{"id": 1, "name": "a"},
+				map[string]interface{}{"id": 2, "name": "a"},
+			}
+		},
+	},
+	{
+		desc: "list-unique-by-ok-2",
+		schema: pongo.List(pongo.Object(pongo.O{
+			"owner": pongo.Object(pongo.O{"id": pongo.Int()}),
+			"name":  pongo.String(),
+		})).SetUniqueBy("owner.id"),
+		data: func() pongo.Data {
+			return []interface{}{
+				map[string]interface{}{"name": "a"},
+				map[string]interface{}{"name": "b"},
+				map[string]interface{}{"owner": map[string]interface{}{}, "name": "c"},
+				map[string]interface{}{"owner": map[string]interface{}{"id": 1}, "name": "d"},
+			}
+		},
+		want: func() pongo.Data {
+			return []interface{}{
+				map[string]interface{}{"name": "a"},
+				map[string]interface{}{"name": "b"},
+				map[string]interface{}{"owner": map[string]interface{}{}, "name": "c"},
+				map[string]interface{}{"owner": map[string]interface{}{"id": 1}, "name": "d"},
+			}
+		},
+	},
+	{
+		desc: "list-unique-by-ko-1",
+		schema: pongo.List(pongo.Object(pongo.O{
+			"owner": pongo.Object(pongo.O{"id": pongo.Int()}),
+			"name":  pongo.String(),
+		})).SetUniqueBy("owner.id"),
+		data: func() pongo.Data {
+			return []interface{}{
+				map[string]interface{}{"owner": map[string]interface{}{"id": 1}, "name": "a"},
+				map[string]interface{}{"owner": map[string]interface{}{"id": 1}, "name": "b"},
+			}
+		},
+		errors: 1,
+	},
+	{
+		desc:   "list-contains-ok-1",
+		schema: pongo.List(pongo.Int()).SetContains(pongo.Int().SetMin(10)),
+		data:   func() pongo.Data { return []interface{}{1, 10} },
+		want:   func() pongo.Data { return []interface{}{1, 10} },
+	},
+	{
+		desc:   "list-contains-ok-2",
+		schema: pongo.List(pongo.Int()).SetContains(pongo.Int().SetMin(10)).SetMinContains(0).SetMaxContains(1),
+		data:   func() pongo.Data { return []interface{}{1, 2} },
+		want:   func() pongo.Data { return []interface{}{1, 2} },
+	},
+	{
+		desc:   "list-contains-ko-1",
+		schema: pongo.List(pongo.Int()).SetContains(pongo.Int().SetMin(10)),
+		data:   func() pongo.Data { return []interface{}{1, 2} },
+		errors: 1,
+	},
+	{
+		desc:   "list-contains-ko-2",
+		schema: pongo.List(pongo.Int()).SetContains(pongo.Int().SetMin(10)).SetMinContains(2),
+		data:   func() pongo.Data { return []interface{}{1, 10} },
+		errors: 1,
+	},
+	{
+		desc:   "list-contains-ko-3",
+		schema: pongo.List(pongo.Int()).SetContains(pongo.Int().SetMin(10)).SetMaxContains(1),
+		data:   func() pongo.Data { return []interface{}{10, 11} },
+		errors: 1,
+	},
+}
+
+func TestTypeList_UniqueContains(t *testing.T) {
+	testSchemaCaseProcess(testListTypeUniqueContainsCases, pongo.SchemaActionParse)(t)
+}
+
+func TestTypeList_UniqueContainsMarshal(t *testing.T) {
+	schema := pongo.List(pongo.Int()).SetUniqueItems(true).SetContains(pongo.Int().SetMin(10)).SetMaxContains(2)
+
+	testSchemaMarshalEqual(t, schema, `{"$version":"1.1","$body":{"$type":"list","type":"int","uniqueItems":true,"contains":{"$type":"int","min":10},"maxContains":2}}`)
+
+	if _, err := pongo.MarshalJSONSchema(pongo.Schema(schema), pongo.SchemaActionParse); !errors.Is(err, pongo.ErrSchemaNotJSONSchemaMarshalable) {
+		t.Errorf("expected ErrSchemaNotJSONSchemaMarshalable on draft-07 JSON Schema marshal, got %v", err)
+	}
+	if _, err := pongo.MarshalJSONSchemaWithDraft(pongo.Schema(pongo.List(schema)), pongo.SchemaActionParse, pongo.JSONSchemaDraft07); !errors.Is(err, pongo.ErrSchemaNotJSONSchemaMarshalable) {
+		t.Errorf("expected ErrSchemaNotJSONSchemaMarshalable on draft-07 JSON Schema marshal of a nested list, got %v", err)
+	}
+
+	jsonSchema, err := pongo.MarshalJSONSchemaWithDraft(pongo.Schema(pongo.List(schema)), pongo.SchemaActionParse, pongo.JSONSchemaDraft202012)
+	if err != nil {
+		t.Errorf("unexpected error on JSON Schema marshal: %s", err)
+		return
+	}
+	want := `{"$schema":"https://json-schema.org/draft/2020-12/schema","items":{"contains":{"minimum":10,"type":"integer"},"items":{"type":"integer"},"maxContains":2,"type":"array","uniqueItems":true},"type":"array"}`
+	if string(jsonSchema) != want {
+		t.Errorf("expected JSON Schema %s, got %s", want, jsonSchema)
+	}
+
+	testSchemaJSONSchema(t, pongo.List(pongo.Int()).SetContains(pongo.Int().SetMin(10)).SetMinContains(1), map[pongo.SchemaAction]string{
+		pongo.SchemaActionParse: `{"contains":{"minimum":10,"type":"integer"},"items":{"type":"integer"},"type":"array"}`,
+	})
+
+	testSchemaLint(t, pongo.List(pongo.Int()).SetMinContains(3).SetMaxContains(2), "useless-contains", "contradictory-contains")
+}
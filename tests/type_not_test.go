@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+var testTypeNotCases = []testSchemaCase{
+	{
+		desc:   "type-not-ok-1",
+		schema: pongo.Not(pongo.String()),
+		data:   func() pongo.Data { return 123 },
+		want:   func() pongo.Data { return 123 },
+	},
+	{
+		desc:   "type-not-ok-2",
+		schema: pongo.AllOf(pongo.String(), pongo.Not(pongo.AnyOf(pongo.Expr("@ == 'admin'"), pongo.Expr("@ == 'root'")))),
+		data:   func() pongo.Data { return "guest" },
+		want:   func() pongo.Data { return "guest" },
+	},
+	{
+		desc:   "type-not-ok-3",
+		schema: pongo.Not(pongo.Int().SetCast(true)),
+		data:   func() pongo.Data { return "abc" },
+		want:   func() pongo.Data { return "abc" },
+	},
+	{
+		desc:   "type-not-ko-1",
+		schema: pongo.Not(pongo.String()),
+		data:   func() pongo.Data { return "abc" },
+		errors: 1,
+	},
+	{
+		desc:   "type-not-ko-2",
+		schema: pongo.AllOf(pongo.String(), pongo.Not(pongo.AnyOf(pongo.Expr("@ == 'admin'"), pongo.Expr("@ == 'root'")))),
+		data:   func() pongo.Data { return "root" },
+		errors: 1,
+	},
+	{
+		desc:   "type-not-ko-3",
+		schema: pongo.Not(nil),
+		data:   func() pongo.Data { return "abc" },
+		errors: 1,
+	},
+}
+
+func TestTypeNot_Parse(t *testing.T) {
+	testSchemaCaseParse(testTypeNotCases)(t)
+}
+
+func TestTypeNotMarshal(t *testing.T) {
+	schema := pongo.Object(pongo.O{"name": pongo.Not(pongo.String().SetMaxLen(2))})
+
+	testSchemaMarshalEqual(t, schema, `{"$version":"1.1","$body":{"$type":"object","properties":{"name":{"$type":"not","type":{"$type":"string","maxLen":2}}}}}`)
+
+	testSchemaJSONSchema(t, pongo.Not(pongo.String().SetMaxLen(2)), map[pongo.SchemaAction]string{
+		pongo.SchemaActionParse: `{"not":{"maxLength":2,"type":"string"}}`,
+	})
+	// without a schema to negate no data is valid
+	testSchemaJSONSchema(t, pongo.Not(nil), map[pongo.SchemaAction]string{
+		pongo.SchemaActionParse: `{"not":{}}`,
+	})
+	// the expression exports no JSON Schema, so its negation cannot be exported
+	if _, err := pongo.MarshalJSONSchema(pongo.Schema(pongo.Not(pongo.Expr("@ == 'root'"))), pongo.SchemaActionParse); !errors.Is(err, pongo.ErrSchemaNotJSONSchemaMarshalable) {
+		t.Errorf("expected ErrSchemaNotJSONSchemaMarshalable, got %v", err)
+	}
+
+	testSchemaLint(t, pongo.Not(nil), "nil-not-type")
+}
@@ -1,6 +1,10 @@
 package tests
 
 import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -205,3 +209,236 @@ func TestObjectType_Parse(t *testing.T) {
 func TestObjectType_Serialize(t *testing.T) {
 	testSchemaCaseSerialize(testObjectTypeSerializeCases)(t)
 }
+
+func testOrderedObjectSchema() *pongo.ObjectType {
+	return pongo.OrderedObject(
+		pongo.Property("zName", pongo.String()),
+		pongo.Property("aAge", pongo.Int()),
+		pongo.Property("mTags", pongo.List(pongo.String())),
+	)
+}
+
+func TestObjectTypeOrder(t *testing.T) {
+	schema := testOrderedObjectSchema()
+	if keys := schema.Keys(); !reflect.DeepEqual(keys, []string{"zName", "aAge", "mTags"}) {
+		t.Errorf("unexpected keys order %v", keys)
+	}
+
+	replaced := schema.SetProperty("aAge", pongo.Float64()).SetProperty("bMail", pongo.String())
+	if keys := replaced.Keys(); !reflect.DeepEqual(keys, []string{"zName", "aAge", "mTags", "bMail"}) {
+		t.Errorf("unexpected keys order after SetProperty %v", keys)
+	}
+	if _, ok := schema.SchemaMap["bMail"]; ok {
+		t.Errorf("expected SetProperty to not alter the original ObjectType")
+	}
+
+	data := map[string]interface{}{"zName": 1, "aAge": "a", "mTags": []interface{}{1}, "unknown-b": 1, "unknown-a": 1}
+	var wantPaths = []string{".<object>.zName<string>", ".<object>.aAge<int>", ".<object>.mTags<list>.[0]<string>", ".<object>", ".<object>"}
+	for i := 0; i < 10; i++ {
+		_, err := pongo.Parse(schema, data)
+		schemaErr, ok := err.(*pongo.SchemaError)
+		if !ok {
+			t.Errorf("expected a *SchemaError, got %v", err)
+			return
+		}
+
+		var paths []string
+		for _, e := range schemaErr.Errors {
+			paths = append(paths, e.Path().String())
+		}
+		if !reflect.DeepEqual(paths, wantPaths) {
+			t.Errorf("expected errors paths %v, got %v", wantPaths, paths)
+			return
+		}
+		// the unknown keys are reported last in alphabetical order
+		if !strings.HasSuffix(schemaErr.Errors[3].Error().Error(), "unknown-a") || !strings.HasSuffix(schemaErr.Errors[4].Error().Error(), "unknown-b") {
+			t.Errorf("expected unknown keys errors in alphabetical order, got %s", schemaErr)
+			return
+		}
+	}
+}
+
+func TestObjectTypeOrderMarshal(t *testing.T) {
+	schema := testOrderedObjectSchema()
+
+	testSchemaMarshalEqual(t, schema, `{"$version":"1.1","$body":{"$type":"object","properties":{"zName":"string","aAge":"int","mTags":{"$type":"list","type":"string"}}}}`)
+	testSchemaJSONSchema(t, schema, map[pongo.SchemaAction]string{
+		pongo.SchemaActionParse: `{"additionalProperties":false,"properties":{"zName":{"type":"string"},"aAge":{"type":"integer"},"mTags":{"items":{"type":"string"},"type":"array"}},"type":"object"}`,
+	})
+}
+
+func TestObjectTypeRequired(t *testing.T) {
+	schema := pongo.OrderedObject(
+		pongo.Property("id", pongo.Int()),
+		pongo.Property("name", pongo.String()),
+		pongo.Property("age", pongo.Int()),
+	).Require("name", "age").RequireOnAction(pongo.SchemaActionSerialize, "id")
+
+	if _, err := pongo.Parse(schema, map[string]interface{}{"name": "John", "age": 42}); err != nil {
+		t.Errorf("unexpected error on parse: %s", err)
+	}
+
+	var cases = []struct {
+		action    pongo.SchemaAction
+		data      map[string]interface{}
+		wantPaths []string
+		missing   int
+	}{
+		{
+			action:    pongo.SchemaActionParse,
+			data:      map[string]interface{}{"age": "42"},
+			wantPaths: []string{".<object>.name<string>", ".<object>.age<int>"},
+			missing:   1,
+		},
+		{
+			action:    pongo.SchemaActionSerialize,
+			data:      map[string]interface{}{},
+			wantPaths: []string{".<object>.name<string>", ".<object>.age<int>", ".<object>.id<int>"},
+			missing:   3,
+		},
+	}
+
+	for _, c := range cases {
+		_, err := pongo.Process(schema, c.action, c.data)
+		schemaErr, ok := err.(*pongo.SchemaError)
+		if !ok {
+			t.Errorf("expected a *SchemaError on %s, got %v", c.action, err)
+			continue
+		}
+
+		var paths []string
+		var missing int
+		for _, e := range schemaErr.Errors {
+			paths = append(paths, e.Path().String())
+			if errors.Is(e.Error(), pongo.ErrMissingRequiredProperty) {
+				missing++
+			}
+		}
+		if !reflect.DeepEqual(paths, c.wantPaths) {
+			t.Errorf("expected errors paths %v on %s, got %v", c.wantPaths, c.action, paths)
+		}
+		if missing != c.missing {
+			t.Errorf("expected %d ErrMissingRequiredProperty on %s, got %d", c.missing, c.action, missing)
+		}
+	}
+
+	for action, want := range map[pongo.SchemaAction][]string{
+		pongo.SchemaActionParse:     {"name", "age"},
+		pongo.SchemaActionSerialize: {"name", "age", "id"},
+	} {
+		if keys := schema.RequiredKeys(action); !reflect.DeepEqual(keys, want) {
+			t.Errorf("expected required keys %v on %s, got %v", want, action, keys)
+		}
+
+		jsonSchema, err := pongo.MarshalJSONSchema(pongo.Schema(schema), action)
+		if err != nil {
+			t.Errorf("unexpected error on JSON Schema marshal: %s", err)
+			continue
+		}
+		var decoded struct {
+			Required []string `json:"required"`
+		}
+		if err = json.Unmarshal(jsonSchema, &decoded); err != nil || !reflect.DeepEqual(decoded.Required, want) {
+			t.Errorf("expected JSON Schema required %v on %s, got %s", want, action, jsonSchema)
+		}
+	}
+
+	testSchemaMarshalEqual(t, schema, `{"$version":"1.1","$body":{"$type":"object","required":["name","age"],"requiredByAction":{"SERIALIZE":["id"]},"properties":{"id":"int","name":"string","age":"int"}}}`)
+}
+
+func testAccessObjectSchema() *pongo.ObjectType {
+	return pongo.OrderedObject(
+		pongo.Property("id", pongo.Int()),
+		pongo.Property("name", pongo.String()),
+		pongo.Property("password", pongo.String()),
+	).Require("id", "name", "password").SetReadOnly("id").SetWriteOnly("password")
+}
+
+func TestObjectTypeAccess(t *testing.T) {
+	var cases = []struct {
+		desc   string
+		schema *pongo.ObjectType
+		action pongo.SchemaAction
+		data   map[string]interface{}
+		want   map[string]interface{}
+		errors []error
+	}{
+		{
+			desc:   "parse-strip",
+			schema: testAccessObjectSchema(),
+			action: pongo.SchemaActionParse,
+			data:   map[string]interface{}{"id": 1, "name": "John", "password": "secret"},
+			want:   map[string]interface{}{"name": "John", "password": "secret"},
+		},
+		{
+			desc:   "parse-without-read-only",
+			schema: testAccessObjectSchema(),
+			action: pongo.SchemaActionParse,
+			data:   map[string]interface{}{"name": "John", "password": "secret"},
+			want:   map[string]interface{}{"name": "John", "password": "secret"},
+		},
+		{
+			desc:   "serialize-strip",
+			schema: testAccessObjectSchema(),
+			action: pongo.SchemaActionSerialize,
+			data:   map[string]interface{}{"id": 1, "name": "John", "password": "secret"},
+			want:   map[string]interface{}{"id": 1, "name": "John"},
+		},
+		{
+			desc:   "parse-reject",
+			schema: testAccessObjectSchema().SetAccessPolicy(pongo.AccessPolicyReject),
+			action: pongo.SchemaActionParse,
+			data:   map[string]interface{}{"id": 1, "name": 2, "password": "secret"},
+			errors: []error{pongo.ErrReadOnlyProperty, nil},
+		},
+		{
+			desc:   "serialize-reject",
+			schema: testAccessObjectSchema().SetAccessPolicy(pongo.AccessPolicyReject),
+			action: pongo.SchemaActionSerialize,
+			data:   map[string]interface{}{"id": 1, "name": "John", "password": "secret"},
+			errors: []error{pongo.ErrWriteOnlyProperty},
+		},
+		{
+			desc:   "serialize-missing-required",
+			schema: testAccessObjectSchema(),
+			action: pongo.SchemaActionSerialize,
+			data:   map[string]interface{}{"name": "John"},
+			errors: []error{pongo.ErrMissingRequiredProperty},
+		},
+	}
+
+	for _, c := range cases {
+		got, err := pongo.Process(c.schema, c.action, c.data)
+		if len(c.errors) == 0 {
+			if err != nil {
+				t.Errorf("test %s: unexpected error %s", c.desc, err)
+			} else if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("test %s: expected %v, got %v", c.desc, c.want, got)
+			}
+			continue
+		}
+
+		schemaErr, ok := err.(*pongo.SchemaError)
+		if !ok || len(schemaErr.Errors) != len(c.errors) {
+			t.Errorf("test %s: expected %d error(s), got %v", c.desc, len(c.errors), err)
+			continue
+		}
+		for i, want := range c.errors {
+			if want != nil && !errors.Is(schemaErr.Errors[i].Error(), want) {
+				t.Errorf("test %s: expected error %d to be %s, got %s", c.desc, i, want, schemaErr.Errors[i].Error())
+			}
+		}
+	}
+
+	if _, err := pongo.Parse(testAccessObjectSchema().SetAccessPolicy("drop"), map[string]interface{}{}); err == nil {
+		t.Errorf("expected an error with an unknown access policy")
+	}
+}
+
+func TestObjectTypeAccessJSONSchema(t *testing.T) {
+	testSchemaJSONSchema(t, testAccessObjectSchema(), map[pongo.SchemaAction]string{
+		pongo.SchemaActionParse: `{"additionalProperties":false,"properties":{"id":{"type":"integer","readOnly":true},"name":{"type":"string"},"password":{"type":"string","writeOnly":true}},"required":["name","password"],"type":"object"}`,
+	})
+
+	testSchemaMarshalEqual(t, testAccessObjectSchema().SetAccessPolicy(pongo.AccessPolicyReject), `{"$version":"1.1","$body":{"$type":"object","required":["id","name","password"],"readOnly":["id"],"writeOnly":["password"],"accessPolicy":"reject","properties":{"id":"int","name":"string","password":"string"}}}`)
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/kael-k/pongo/v2/pongo"
@@ -138,3 +139,130 @@ func TestTypeString_Parse(t *testing.T) {
 func TestTypeString_Serialize(t *testing.T) {
 	testSchemaCaseSerialize(testStringTypeSerializeCases)(t)
 }
+
+var testStringTransformCases = []testSchemaCase{
+	{
+		desc:   "type-string-transform-ok-1",
+		schema: pongo.String().SetTransforms(pongo.StringTransformTrim, pongo.StringTransformLower),
+		data:   func() pongo.Data { return "  John.Doe@Example.COM \n" },
+		want:   func() pongo.Data { return "john.doe@example.com" },
+	},
+	{
+		desc:   "type-string-transform-ok-2",
+		schema: pongo.String().SetTransforms(pongo.StringTransformStripControl, pongo.StringTransformCollapse, pongo.StringTransformUpper),
+		data:   func() pongo.Data { return "a\x00b \t\n c" },
+		want:   func() pongo.Data { return "AB C" },
+	},
+	{
+		desc:   "type-string-transform-ok-3",
+		schema: pongo.String().SetTransforms(pongo.StringTransformNFC),
+		data:   func() pongo.Data { return "e\u0301" },
+		want:   func() pongo.Data { return "\u00e9" },
+	},
+	{
+		desc:   "type-string-transform-ok-4",
+		schema: pongo.String().SetTransforms(pongo.StringTransformNFKD, pongo.StringTransformFold),
+		data:   func() pongo.Data { return "ﬁ STRASSE" },
+		want:   func() pongo.Data { return "fi strasse" },
+	},
+	{
+		desc:   "type-string-transform-ok-5",
+		schema: pongo.String().SetTransforms(pongo.StringTransformTrim).SetMaxLen(3),
+		data:   func() pongo.Data { return "  abc  " },
+		want:   func() pongo.Data { return "abc" },
+	},
+	{
+		desc:   "type-string-transform-ok-6",
+		schema: pongo.String().SetTransforms(pongo.StringTransformTrim).SetTransformActions(pongo.SchemaActionSerialize),
+		data:   func() pongo.Data { return " abc " },
+		want:   func() pongo.Data { return " abc " },
+	},
+	{
+		desc:   "type-string-transform-ko-1",
+		schema: pongo.String().SetTransforms(pongo.StringTransformTrim).SetMinLen(3),
+		data:   func() pongo.Data { return " a  " },
+		errors: 1,
+	},
+	{
+		desc:   "type-string-transform-ko-2",
+		schema: pongo.String().SetTransforms("reverse"),
+		data:   func() pongo.Data { return "abc" },
+		errors: 1,
+	},
+}
+
+func TestTypeStringTransform_Parse(t *testing.T) {
+	testSchemaCaseParse(testStringTransformCases)(t)
+}
+
+func TestTypeStringTransformMarshal(t *testing.T) {
+	schema := pongo.String().SetTransforms(pongo.StringTransformTrim, pongo.StringTransformNFC).SetTransformActions(pongo.SchemaActionParse)
+
+	testSchemaMarshalEqual(t, schema, `{"$version":"1.1","$body":{"$type":"string","transforms":["trim","nfc"],"transformActions":["PARSE"]}}`)
+
+	_, _, err := pongo.UnmarshalPongoSchema([]byte(`{"$version": "1.1", "$body": {"$type": "string", "transforms": ["reverse"]}}`))
+	if !errors.Is(err, pongo.ErrInvalidStringTransform) {
+		t.Errorf("expected ErrInvalidStringTransform on unmarshal, got %v", err)
+	}
+}
+
+var testStringLengthUnitCases = []testSchemaCase{
+	{
+		desc:   "type-string-length-unit-ok-1",
+		schema: pongo.String().SetMaxLen(4).SetLengthUnit(pongo.LengthUnitRunes),
+		data:   func() pongo.Data { return "José" },
+		want:   func() pongo.Data { return "José" },
+	},
+	{
+		desc:   "type-string-length-unit-ok-2",
+		schema: pongo.String().SetMinLen(2).SetMaxLen(2).SetLengthUnit(pongo.LengthUnitGraphemes),
+		data:   func() pongo.Data { return "\U0001F44D\U0001F3FD\U0001F1EE\U0001F1F9" },
+		want:   func() pongo.Data { return "\U0001F44D\U0001F3FD\U0001F1EE\U0001F1F9" },
+	},
+	{
+		desc:   "type-string-length-unit-ok-3",
+		schema: pongo.String().SetMinLen(5).SetLengthUnit(pongo.LengthUnitBytes),
+		data:   func() pongo.Data { return "José" },
+		want:   func() pongo.Data { return "José" },
+	},
+	{
+		desc:   "type-string-length-unit-ko-1",
+		schema: pongo.String().SetMaxLen(4),
+		data:   func() pongo.Data { return "José" },
+		errors: 1,
+	},
+	{
+		desc:   "type-string-length-unit-ko-2",
+		schema: pongo.String().SetMaxLen(2).SetLengthUnit(pongo.LengthUnitRunes),
+		data:   func() pongo.Data { return "\U0001F44D\U0001F3FD\U0001F1EE\U0001F1F9" },
+		errors: 1,
+	},
+	{
+		desc:   "type-string-length-unit-ko-3",
+		schema: pongo.String().SetLengthUnit("words"),
+		data:   func() pongo.Data { return "José" },
+		errors: 1,
+	},
+}
+
+func TestTypeStringLengthUnit_Parse(t *testing.T) {
+	testSchemaCaseParse(testStringLengthUnitCases)(t)
+}
+
+func TestTypeStringLengthUnitJSONSchema(t *testing.T) {
+	for unit, want := range map[pongo.LengthUnit]string{
+		"":                        `{"maxLength":8,"minLength":1,"type":"string"}`,
+		pongo.LengthUnitBytes:     `{"maxLength":8,"minLength":1,"type":"string"}`,
+		pongo.LengthUnitRunes:     `{"maxLength":8,"minLength":2,"type":"string"}`,
+		pongo.LengthUnitGraphemes: `{"minLength":2,"type":"string"}`,
+	} {
+		testSchemaJSONSchema(t, pongo.String().SetMinLen(2).SetMaxLen(8).SetLengthUnit(unit), map[pongo.SchemaAction]string{
+			pongo.SchemaActionParse: want,
+		})
+	}
+
+	_, _, err := pongo.UnmarshalPongoSchema([]byte(`{"$version": "1.1", "$body": {"$type": "string", "lengthUnit": "words"}}`))
+	if !errors.Is(err, pongo.ErrInvalidLengthUnit) {
+		t.Errorf("expected ErrInvalidLengthUnit on unmarshal, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+var testTypeTupleCases = []testSchemaCase{
+	{
+		desc:   "type-tuple-ok-1",
+		schema: pongo.Tuple(pongo.Float64(), pongo.Float64()),
+		data:   func() pongo.Data { return []interface{}{45.4, 9.18} },
+		want:   func() pongo.Data { return []interface{}{45.4, 9.18} },
+	},
+	{
+		desc:   "type-tuple-ok-2",
+		schema: pongo.Tuple(pongo.String(), pongo.String(), pongo.Int().SetCast(true)).SetMinLen(2),
+		data:   func() pongo.Data { return []interface{}{"remove", "/a"} },
+		want:   func() pongo.Data { return []interface{}{"remove", "/a"} },
+	},
+	{
+		desc:   "type-tuple-ok-3",
+		schema: pongo.Tuple(pongo.String(), pongo.String(), pongo.Int().SetCast(true)).SetMinLen(2),
+		data:   func() pongo.Data { return []interface{}{"add", "/a", "1"} },
+		want:   func() pongo.Data { return []interface{}{"add", "/a", 1} },
+	},
+	{
+		desc:   "type-tuple-ok-4",
+		schema: pongo.Tuple(pongo.String()).SetAdditional(pongo.Int()).SetMaxLen(3),
+		data:   func() pongo.Data { return []interface{}{"a", 1, 2} },
+		want:   func() pongo.Data { return []interface{}{"a", 1, 2} },
+	},
+	{
+		desc:   "type-tuple-ko-1",
+		schema: pongo.Tuple(pongo.Float64(), pongo.Float64()),
+		data:   func() pongo.Data { return []interface{}{45.4} },
+		errors: 1,
+	},
+	{
+		desc:   "type-tuple-ko-2",
+		schema: pongo.Tuple(pongo.Float64(), pongo.Float64()),
+		data:   func() pongo.Data { return []interface{}{45.4, 9.18, 1.0} },
+		errors: 1,
+	},
+	{
+		desc:   "type-tuple-ko-3",
+		schema: pongo.Tuple(pongo.Float64(), pongo.Float64()),
+		data:   func() pongo.Data { return []interface{}{"45.4", "9.18"} },
+		errors: 2,
+	},
+	{
+		desc:   "type-tuple-ko-4",
+		schema: pongo.Tuple(pongo.String()).SetAdditional(pongo.Int()).SetMaxLen(3),
+		data:   func() pongo.Data { return []interface{}{"a", 1, 2, 3} },
+		errors: 1,
+	},
+	{
+		desc:   "type-tuple-ko-5",
+		schema: pongo.Tuple(pongo.String()).SetAdditional(pongo.Int()),
+		data:   func() pongo.Data { return []interface{}{"a", 1, "b"} },
+		errors: 1,
+	},
+	{
+		desc:   "type-tuple-ko-6",
+		schema: pongo.Tuple(pongo.String()),
+		data:   func() pongo.Data { return "a" },
+		errors: 1,
+	},
+}
+
+func TestTypeTuple_Parse(t *testing.T) {
+	testSchemaCaseParse(testTypeTupleCases)(t)
+}
+
+func TestTypeTupleErrorPath(t *testing.T) {
+	_, err := pongo.Parse(pongo.Tuple(pongo.String(), pongo.Int()), []interface{}{"a", "b"})
+	schemaErr, ok := err.(*pongo.SchemaError)
+	if !ok || len(schemaErr.Errors) != 1 {
+		t.Errorf("expected a *SchemaError with 1 error, got %v", err)
+		return
+	}
+	if path := schemaErr.Errors[0].Path().String(); path != ".<tuple>.[1]<int>" {
+		t.Errorf("expected error path .<tuple>.[1]<int>, got %s", path)
+	}
+}
+
+func TestTypeTupleJSONSchema(t *testing.T) {
+	schema := pongo.Schema(pongo.Object(pongo.O{
+		"items": pongo.Tuple(pongo.String(), pongo.Int()).SetAdditional(pongo.Bool()),
+		"point": pongo.Tuple(pongo.Float64(), pongo.Float64()),
+	}))
+
+	jsonSchema, err := pongo.MarshalJSONSchemaWithDraft(schema, pongo.SchemaActionParse, pongo.JSONSchemaDraft07)
+	if err != nil {
+		t.Errorf("unexpected error on JSON Schema marshal: %s", err)
+		return
+	}
+	want := `{"$schema":"http://json-schema.org/draft-07/schema#","additionalProperties":false,"properties":{"items":{"additionalItems":{"type":"boolean"},"items":[{"type":"string"},{"type":"integer"}],"minItems":2,"type":"array"},"point":{"additionalItems":false,"items":[{"type":"number"},{"type":"number"}],"minItems":2,"type":"array"}},"type":"object"}`
+	if string(jsonSchema) != want {
+		t.Errorf("expected JSON Schema %s, got %s", want, jsonSchema)
+	}
+
+	jsonSchema, err = pongo.MarshalJSONSchemaWithDraft(schema, pongo.SchemaActionParse, pongo.JSONSchemaDraft202012)
+	if err != nil {
+		t.Errorf("unexpected error on JSON Schema marshal: %s", err)
+		return
+	}
+	want = `{"$schema":"https://json-schema.org/draft/2020-12/schema","additionalProperties":false,"properties":{"items":{"items":{"type":"boolean"},"prefixItems":[{"type":"string"},{"type":"integer"}],"minItems":2,"type":"array"},"point":{"items":false,"prefixItems":[{"type":"number"},{"type":"number"}],"minItems":2,"type":"array"}},"type":"object"}`
+	if string(jsonSchema) != want {
+		t.Errorf("expected JSON Schema %s, got %s", want, jsonSchema)
+	}
+}
+
+func TestTypeTupleLint(t *testing.T) {
+	testSchemaLint(t, pongo.Tuple(pongo.String(), nil).SetMinLen(3), "contradictory-length", "nil-tuple-item")
+}
+
+func TestTypeTupleMarshal(t *testing.T) {
+	schema := pongo.Tuple(pongo.String(), pongo.Int()).SetAdditional(pongo.Bool()).SetMinLen(1)
+
+	testSchemaMarshalEqual(t, schema, `{"$version":"1.1","$body":{"$type":"tuple","items":["string","int"],"additional":"bool","minLen":1}}`)
+}
@@ -105,3 +105,12 @@ func InArray[T comparable](value T, array []T) bool {
 	}
 	return false
 }
+
+func TestListContains(t *testing.T) {
+	if !pongo.ListContains([]string{"A", "B"}, "B") {
+		t.Errorf("error in ListContains: expected [A B] to contain B")
+	}
+	if pongo.ListContains([]string{"A", "B"}, "C") || pongo.ListContains(nil, "A") {
+		t.Errorf("error in ListContains: expected C not to be contained")
+	}
+}
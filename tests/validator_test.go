@@ -0,0 +1,195 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kael-k/pongo/v2/pongo"
+)
+
+func init() {
+	err := pongo.RegisterValidator("acme.io/test-luhn", func(_ json.RawMessage) (pongo.ValidatorFn, error) {
+		return func(_ pongo.SchemaAction, data pongo.Data, _ *pongo.DataPointer) (pongo.Data, error) {
+			s, ok := data.(string)
+			if !ok || !testLuhn(s) {
+				return nil, fmt.Errorf("%v is not a valid luhn number", data)
+			}
+			return data, nil
+		}, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	err = pongo.RegisterValidator("acme.io/test-suffix", func(args json.RawMessage) (pongo.ValidatorFn, error) {
+		var a struct {
+			Suffix string `json:"suffix"`
+		}
+		if err := json.Unmarshal(args, &a); err != nil {
+			return nil, err
+		}
+		return func(action pongo.SchemaAction, data pongo.Data, _ *pongo.DataPointer) (pongo.Data, error) {
+			if action == pongo.SchemaActionSerialize {
+				return data, nil
+			}
+			return fmt.Sprintf("%v%s", data, a.Suffix), nil
+		}, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func testLuhn(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		d := int(s[len(s)-1-i] - '0')
+		if d < 0 || d > 9 {
+			return false
+		}
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+func testValidator(t *testing.T, name string, args any) *pongo.SchemaValidator {
+	validator, err := pongo.NewSchemaValidator(name, args)
+	if err != nil {
+		t.Fatalf("unexpected error on NewSchemaValidator %s: %s", name, err)
+	}
+	return validator
+}
+
+func TestSchemaValidators(t *testing.T) {
+	luhn := testValidator(t, "acme.io/test-luhn", nil)
+	suffix := testValidator(t, "acme.io/test-suffix", map[string]string{"suffix": "-checked"})
+
+	testCases := []testSchemaCase{
+		{
+			desc:   "valid luhn",
+			schema: pongo.Schema(pongo.String()).AddValidators(luhn),
+			data:   func() pongo.Data { return "79927398713" },
+			want:   func() pongo.Data { return "79927398713" },
+		},
+		{
+			desc:   "invalid luhn",
+			schema: pongo.Schema(pongo.String()).AddValidators(luhn),
+			data:   func() pongo.Data { return "79927398710" },
+			errors: 1,
+		},
+		{
+			desc:   "validators are run after the SchemaType",
+			schema: pongo.Schema(pongo.String()).AddValidators(luhn),
+			data:   func() pongo.Data { return 79927398713 },
+			errors: 1,
+		},
+		{
+			desc:   "validators are run in order",
+			schema: pongo.Schema(pongo.String()).AddValidators(luhn, suffix),
+			data:   func() pongo.Data { return "79927398713" },
+			want:   func() pongo.Data { return "79927398713-checked" },
+		},
+		{
+			desc: "validators on object properties",
+			schema: pongo.Object(pongo.O{
+				"card":  pongo.Schema(pongo.String()).AddValidators(luhn),
+				"other": pongo.Schema(pongo.String()).AddValidators(luhn),
+			}),
+			data: func() pongo.Data {
+				return map[string]interface{}{"card": "79927398713", "other": "1234"}
+			},
+			errors: 1,
+		},
+	}
+
+	t.Run("parse", testSchemaCaseParse(testCases))
+}
+
+func TestSchemaValidatorsMarshal(t *testing.T) {
+	schema := pongo.Object(pongo.O{
+		"card": pongo.Schema(pongo.String()).AddValidators(
+			testValidator(t, "acme.io/test-luhn", nil),
+			testValidator(t, "acme.io/test-suffix", map[string]string{"suffix": "-checked"}),
+		),
+	})
+
+	marshalled, err := pongo.MarshalPongoSchemaWithOptions(schema, nil, pongo.PongoSchemaMarshalOptions{Compact: true})
+	if err != nil {
+		t.Errorf("unexpected error on marshal: %s", err)
+		return
+	}
+	want := `{"$version":"1.1","$body":{"$type":"object","properties":{"card":{"$type":"string","$validators":[{"name":"acme.io/test-luhn"},{"name":"acme.io/test-suffix","args":{"suffix":"-checked"}}]}}}}`
+	if string(marshalled) != want {
+		t.Errorf("expected marshalled schema %s, got %s", want, marshalled)
+	}
+
+	unmarshalled, _, err := pongo.UnmarshalPongoSchema(marshalled)
+	if err != nil {
+		t.Errorf("unexpected error on unmarshal: %s", err)
+		return
+	}
+	data, err := pongo.Parse(unmarshalled, map[string]interface{}{"card": "79927398713"})
+	if err != nil {
+		t.Errorf("unexpected error on parse with the unmarshalled schema: %s", err)
+	} else if data.(map[string]interface{})["card"] != "79927398713-checked" {
+		t.Errorf("expected the unmarshalled validators to be run, got %v", data)
+	}
+	if _, err = pongo.Parse(unmarshalled, map[string]interface{}{"card": "1234"}); err == nil {
+		t.Errorf("expected error on parse of an invalid card with the unmarshalled schema")
+	}
+}
+
+func TestSchemaValidatorsUnmarshalErrors(t *testing.T) {
+	_, _, err := pongo.UnmarshalPongoSchema([]byte(`{"$version":"1.1","$body":{"$type":"string","$validators":[{"name":"acme.io/unknown"}]}}`))
+	if !errors.Is(err, pongo.ErrValidatorNotRegistered) {
+		t.Errorf("expected ErrValidatorNotRegistered on unmarshal of an unknown validator, got %v", err)
+	}
+
+	_, _, err = pongo.UnmarshalPongoSchema([]byte(`{"$version":"1.1","$body":{"$type":"string","$validators":[{"name":"acme.io/test-suffix","args":{"suffix":1}}]}}`))
+	if err == nil || !strings.Contains(err.Error(), "invalid args for validator acme.io/test-suffix") {
+		t.Errorf("expected invalid args error on unmarshal, got %v", err)
+	}
+
+	_, _, err = pongo.UnmarshalPongoSchemaYAML([]byte("$version: \"1.1\"\n$body:\n  $type: list\n  type:\n    $type: string\n    $validators:\n      - name: acme.io/unknown\n"))
+	if !errors.Is(err, pongo.ErrValidatorNotRegistered) || !strings.HasPrefix(err.Error(), "line 5: ") {
+		t.Errorf("expected ErrValidatorNotRegistered at line 5 on YAML unmarshal, got %v", err)
+	}
+}
+
+func TestRegisterValidator(t *testing.T) {
+	mapper := pongo.NewPongoSchemaUnmarshalMapper()
+	factory := func(_ json.RawMessage) (pongo.ValidatorFn, error) { return nil, nil }
+
+	if err := mapper.RegisterValidator("acme.io/check", factory); err != nil {
+		t.Errorf("unexpected error on RegisterValidator: %s", err)
+	}
+	if err := mapper.RegisterValidator("acme.io/check", factory); !errors.Is(err, pongo.ErrValidatorAlreadyRegistered) {
+		t.Errorf("expected ErrValidatorAlreadyRegistered on duplicate RegisterValidator, got %v", err)
+	}
+	if err := mapper.RegisterValidator("acme/check", factory); !errors.Is(err, pongo.ErrInvalidSchemaTypeID) {
+		t.Errorf("expected ErrInvalidSchemaTypeID on RegisterValidator of an invalid name, got %v", err)
+	}
+	if validators := mapper.Validators(); len(validators) != 1 || validators[0] != "acme.io/check" {
+		t.Errorf("unexpected registered validators %v", validators)
+	}
+	if _, err := mapper.Validator("acme.io/unknown", nil); !errors.Is(err, pongo.ErrValidatorNotRegistered) {
+		t.Errorf("expected ErrValidatorNotRegistered on unknown validator, got %v", err)
+	}
+
+	findings := pongo.Lint(pongo.Schema(pongo.String()).AddValidators(&pongo.SchemaValidator{Name: "acme.io/check"}))
+	if len(findings) != 1 || findings[0].Rule != "unresolved-validator" {
+		t.Errorf("expected an unresolved-validator lint finding, got %v", findings)
+	}
+}